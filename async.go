@@ -0,0 +1,224 @@
+package log
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MARK: Types
+
+// OverflowPolicy determines what an asynchronous output does with a new log
+// line when its queue is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the logging goroutine until there is room in the
+	// queue. No lines are dropped.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropNewest discards the line being logged.
+	OverflowDropNewest
+
+	// OverflowDropOldest discards the oldest queued line to make room for the
+	// line being logged.
+	OverflowDropOldest
+)
+
+// AsyncConfig defines the options for writing to an output asynchronously
+type AsyncConfig struct {
+	// QueueSize is the maximum number of lines waiting to be written. Defaults
+	// to 1024.
+	QueueSize int
+
+	// Overflow is the behavior when the queue is full. Defaults to
+	// OverflowBlock.
+	Overflow OverflowPolicy
+
+	// DropReportInterval is how often the number of dropped lines is logged as a
+	// warning. Defaults to one minute.
+	DropReportInterval time.Duration
+}
+
+// asyncWriter queues lines in a bounded channel that is drained to the
+// underlying writer by a background goroutine
+type asyncWriter struct {
+	w              io.Writer
+	queue          chan asyncLine
+	overflow       OverflowPolicy
+	reportInterval time.Duration
+	dropped        uint64
+
+	// closing is closed when the writer starts closing, which releases writes
+	// and flushes blocked on a full queue so that close can take mu
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// asyncLine is either a line to write or, if flushed is set, a marker that is
+// closed once every line queued before it has been written
+type asyncLine struct {
+	p       []byte
+	level   Level
+	tags    []string
+	flushed chan struct{}
+}
+
+// MARK: Private Functions
+
+// newAsyncWriter returns an asyncWriter for w and starts draining its queue
+func newAsyncWriter(w io.Writer, config AsyncConfig) *asyncWriter {
+	size := config.QueueSize
+	if size <= 0 {
+		size = 1024
+	}
+	interval := config.DropReportInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	aw := &asyncWriter{
+		w:              w,
+		queue:          make(chan asyncLine, size),
+		overflow:       config.Overflow,
+		reportInterval: interval,
+		closing:        make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	go aw.drain()
+	return aw
+}
+
+// MARK: Methods
+
+// Write queues a copy of p to be written by the background goroutine. It only
+// blocks when the queue is full and the overflow policy is OverflowBlock.
+func (aw *asyncWriter) Write(p []byte) (int, error) {
+	return aw.WriteLevel(logLevelUnset, nil, p)
+}
+
+// WriteLevel queues copies of p and its tags to be written with its level by
+// the background goroutine
+func (aw *asyncWriter) WriteLevel(level Level, tags []string, p []byte) (int, error) {
+	aw.mu.RLock()
+	defer aw.mu.RUnlock()
+	if aw.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	line := asyncLine{p: make([]byte, len(p)), level: level}
+	copy(line.p, p)
+	if tags != nil {
+		line.tags = append([]string(nil), tags...)
+	}
+
+	switch aw.overflow {
+	case OverflowDropNewest:
+		select {
+		case aw.queue <- line:
+		default:
+			atomic.AddUint64(&aw.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case aw.queue <- line:
+				return len(p), nil
+			default:
+			}
+			select {
+			case oldest := <-aw.queue:
+				if oldest.flushed != nil {
+					// every line before the marker has been written or dropped
+					close(oldest.flushed)
+					continue
+				}
+				atomic.AddUint64(&aw.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case aw.queue <- line:
+		case <-aw.closing:
+			return 0, io.ErrClosedPipe
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush waits until every line queued before the call has been written, then
+// flushes the underlying writer
+func (aw *asyncWriter) Flush(ctx context.Context) error {
+	aw.mu.RLock()
+	if aw.closed {
+		aw.mu.RUnlock()
+		return nil
+	}
+	marker := asyncLine{flushed: make(chan struct{})}
+	select {
+	case aw.queue <- marker:
+		aw.mu.RUnlock()
+	case <-aw.closing:
+		aw.mu.RUnlock()
+		return nil
+	case <-ctx.Done():
+		aw.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-marker.flushed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return flushOutput(ctx, aw.w)
+}
+
+// drain writes queued lines to the underlying writer until the queue is closed
+func (aw *asyncWriter) drain() {
+	defer close(aw.done)
+	for line := range aw.queue {
+		if line.flushed != nil {
+			close(line.flushed)
+			continue
+		}
+		if line.level == logLevelUnset {
+			_, _ = aw.w.Write(line.p)
+			continue
+		}
+		_, _ = writeLevel(aw.w, line.level, line.tags, line.p)
+	}
+}
+
+// takeDropped returns the number of lines dropped since the last call
+func (aw *asyncWriter) takeDropped() uint64 {
+	return atomic.SwapUint64(&aw.dropped, 0)
+}
+
+// close stops accepting lines and waits for the queued lines to be written or
+// for the context to be done. Writes blocked on a full queue return an error.
+func (aw *asyncWriter) close(ctx context.Context) error {
+	aw.closeOnce.Do(func() { close(aw.closing) })
+
+	aw.mu.Lock()
+	if !aw.closed {
+		aw.closed = true
+		close(aw.queue)
+	}
+	aw.mu.Unlock()
+
+	select {
+	case <-aw.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
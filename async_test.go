@@ -0,0 +1,275 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MARK: Test Types
+
+// gatedWriter blocks writes until its gate is opened. A value is sent on
+// writing when a write starts, if one isn't already waiting.
+type gatedWriter struct {
+	gate    chan struct{}
+	writing chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{gate: make(chan struct{}), writing: make(chan struct{}, 1)}
+}
+
+func (gw *gatedWriter) Write(p []byte) (int, error) {
+	select {
+	case gw.writing <- struct{}{}:
+	default:
+	}
+	<-gw.gate
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	return gw.buf.Write(p)
+}
+
+func (gw *gatedWriter) WriteLevel(level Level, tags []string, p []byte) (int, error) {
+	<-gw.gate
+	gw.mu.Lock()
+	gw.buf.WriteString(strings.Join(tags, ",") + " ")
+	gw.mu.Unlock()
+	return gw.Write(p)
+}
+
+func (gw *gatedWriter) lines() []string {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	return strings.Split(strings.TrimSuffix(gw.buf.String(), "\n"), "\n")
+}
+
+// MARK: Tests
+
+func TestAsyncWriter(t *testing.T) {
+	t.Run("Block", func(t *testing.T) {
+		gw := newGatedWriter()
+		aw := newAsyncWriter(gw, AsyncConfig{QueueSize: 2})
+
+		written := make(chan struct{})
+		go func() {
+			for i := 0; i < 10; i++ {
+				_, _ = aw.Write([]byte(strconv.Itoa(i) + "\n"))
+			}
+			close(written)
+		}()
+
+		select {
+		case <-written:
+			t.Fatal("writes did not block on a full queue")
+		case <-time.After(25 * time.Millisecond):
+		}
+
+		close(gw.gate)
+		<-written
+		_ = aw.close(context.Background())
+
+		if lines := gw.lines(); len(lines) != 10 {
+			t.Errorf("expected 10 lines, got %d: %v", len(lines), lines)
+		}
+		if n := aw.takeDropped(); n != 0 {
+			t.Errorf("expected no dropped lines, got %d", n)
+		}
+	})
+
+	t.Run("Drop Newest", func(t *testing.T) {
+		gw := newGatedWriter()
+		aw := newAsyncWriter(gw, AsyncConfig{QueueSize: 2, Overflow: OverflowDropNewest})
+
+		for i := 0; i < 10; i++ {
+			_, _ = aw.Write([]byte(strconv.Itoa(i) + "\n"))
+		}
+		dropped := aw.takeDropped()
+		close(gw.gate)
+		_ = aw.close(context.Background())
+
+		lines := gw.lines()
+		if int(dropped)+len(lines) != 10 || dropped < 7 {
+			t.Errorf("expected at least 7 of 10 lines dropped, got %d dropped and %v written", dropped, lines)
+		}
+		if lines[0] != "0" || lines[1] != "1" {
+			t.Errorf("expected oldest lines to be kept, got %v", lines)
+		}
+	})
+
+	t.Run("Drop Oldest", func(t *testing.T) {
+		gw := newGatedWriter()
+		aw := newAsyncWriter(gw, AsyncConfig{QueueSize: 2, Overflow: OverflowDropOldest})
+
+		for i := 0; i < 10; i++ {
+			_, _ = aw.Write([]byte(strconv.Itoa(i) + "\n"))
+		}
+		dropped := aw.takeDropped()
+		close(gw.gate)
+		_ = aw.close(context.Background())
+
+		lines := gw.lines()
+		if int(dropped)+len(lines) != 10 || dropped < 7 {
+			t.Errorf("expected at least 7 of 10 lines dropped, got %d dropped and %v written", dropped, lines)
+		}
+		if last := lines[len(lines)-1]; last != "9" {
+			t.Errorf("expected newest line to be kept, got %v", lines)
+		}
+	})
+
+	t.Run("Close Blocked", func(t *testing.T) {
+		gw := newGatedWriter()
+		defer close(gw.gate)
+		aw := newAsyncWriter(gw, AsyncConfig{QueueSize: 1})
+
+		// the first line is held by the blocked writer and the second fills the
+		// queue, so the third blocks until the writer is closed
+		_, _ = aw.Write([]byte("0\n"))
+		<-gw.writing
+		_, _ = aw.Write([]byte("1\n"))
+		written := make(chan error)
+		go func() {
+			_, err := aw.Write([]byte("2\n"))
+			written <- err
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+		defer cancel()
+		closed := make(chan error)
+		go func() { closed <- aw.close(ctx) }()
+
+		select {
+		case err := <-closed:
+			if err != context.DeadlineExceeded {
+				t.Errorf("expected the context's error, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("close did not return when its context was done")
+		}
+		if err := <-written; err == nil {
+			t.Error("expected an error from a write blocked when closing")
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		gw := newGatedWriter()
+		aw := newAsyncWriter(gw, AsyncConfig{})
+
+		tags := []string{"a", "b"}
+		_, _ = aw.WriteLevel(LogLevelInfo, tags, []byte("line\n"))
+		tags[0] = "changed"
+		close(gw.gate)
+		_ = aw.close(context.Background())
+
+		if lines := gw.lines(); len(lines) != 1 || lines[0] != "a,b line" {
+			t.Errorf("expected the tags when the line was queued, got %v", lines)
+		}
+	})
+
+	t.Run("Write After Close", func(t *testing.T) {
+		var buf bytes.Buffer
+		aw := newAsyncWriter(&buf, AsyncConfig{})
+		_ = aw.close(context.Background())
+		if _, err := aw.Write([]byte("late\n")); err == nil {
+			t.Error("expected an error writing to a closed writer")
+		}
+	})
+}
+
+func TestAsyncDropReport(t *testing.T) {
+	gw := newGatedWriter()
+	var buf syncBuffer
+	l := NewLogger(LoggerConfig{
+		TimeFormat: TimeFormatLoggly,
+		Sinks: []Sink{
+			{
+				Output: gw,
+				Level:  LogLevelDebug,
+				Format: LogFormatJSON,
+				Async: &AsyncConfig{
+					QueueSize:          1,
+					Overflow:           OverflowDropNewest,
+					DropReportInterval: 10 * time.Millisecond,
+				},
+			},
+			{Output: &buf, Level: LogLevelWarn, Format: LogFormatJSON},
+		},
+	})
+	defer close(gw.gate)
+
+	for i := 0; i < 10; i++ {
+		l.Debugln("This is a debug statement.")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(buf.String(), `"message":"dropped log lines"`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("dropped lines were not reported: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAsyncDropReportLevel(t *testing.T) {
+	gw := newGatedWriter()
+	var buf syncBuffer
+	l := NewLogger(LoggerConfig{
+		TimeFormat: TimeFormatLoggly,
+		LogCaller:  true,
+		Sinks: []Sink{
+			{
+				Output: gw,
+				Level:  LogLevelError,
+				Format: LogFormatJSON,
+				Async: &AsyncConfig{
+					QueueSize:          1,
+					Overflow:           OverflowDropNewest,
+					DropReportInterval: 10 * time.Millisecond,
+				},
+			},
+			{Output: &buf, Level: LogLevelError, Format: LogFormatJSON},
+		},
+	})
+	defer close(gw.gate)
+
+	for i := 0; i < 10; i++ {
+		l.Errorln("This is an error statement.")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(buf.String(), `"message":"dropped log lines"`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("dropped lines were not reported below the sinks' level: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.Contains(line, "dropped log lines") && strings.Contains(line, `"file"`) {
+			t.Errorf("expected no caller in the report %q", line)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer that is safe to read while it is written to
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.String()
+}
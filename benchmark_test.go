@@ -0,0 +1,136 @@
+package log
+
+import (
+	"io"
+	"testing"
+)
+
+// benchmarkFormats are the formats benchmarked, with the configuration that
+// selects each
+var benchmarkFormats = []struct {
+	name   string
+	config LoggerConfig
+}{
+	{"pretty", LoggerConfig{Format: LogFormatPretty}},
+	{"json", LoggerConfig{Format: LogFormatJSON}},
+	{"json-field-names", LoggerConfig{
+		Format:     LogFormatJSON,
+		FieldNames: FieldNames{Timestamp: "@timestamp", Message: "msg", Order: []string{"message"}, InlineMetadata: true},
+	}},
+	{"logfmt", LoggerConfig{Format: LogFormatLogfmt}},
+	{"google-cloud", LoggerConfig{Format: LogFormatGoogleCloud}},
+	{"ecs", LoggerConfig{Format: LogFormatECS}},
+}
+
+type benchmarkLot struct {
+	Name   string `json:"name"`
+	Spaces int    `json:"spaces"`
+}
+
+// benchmarkLogger returns a logger writing to io.Discard that doesn't exit on
+// fatal messages
+func benchmarkLogger(config LoggerConfig, level Level, logCaller bool) Logger {
+	config.Level = level
+	config.TimeFormat = TimeFormatLoggly
+	config.LogCaller = logCaller
+	config.Tags = []string{"benchmark"}
+	config.Output = io.Discard
+	l := newLogger(config)
+	l.exitFunc = func() {}
+	return l
+}
+
+// benchmarkEvent starts an event at the level
+func benchmarkEvent(l Logger, level Level) *Event {
+	switch level {
+	case LogLevelTrace:
+		return l.Trace()
+	case LogLevelDebug:
+		return l.Debug()
+	case LogLevelWarn:
+		return l.Warn()
+	case LogLevelError:
+		return l.Error()
+	case LogLevelFatal:
+		return l.Fatal()
+	}
+	return l.Info()
+}
+
+func BenchmarkLogger(b *testing.B) {
+	lot := benchmarkLot{Name: "Lot A", Spaces: 120}
+
+	for _, format := range benchmarkFormats {
+		for level := LogLevelTrace; level <= LogLevelFatal; level++ {
+			for _, enabled := range []bool{true, false} {
+				if !enabled && level == LogLevelFatal {
+					// fatal messages are always logged
+					continue
+				}
+				for _, logCaller := range []bool{true, false} {
+					loggerLevel := level
+					name := format.name + "/" + level.String() + "/enabled"
+					if !enabled {
+						loggerLevel = level + 1
+						name = format.name + "/" + level.String() + "/disabled"
+					}
+					if logCaller {
+						name += "/caller"
+					} else {
+						name += "/no-caller"
+					}
+
+					l := benchmarkLogger(format.config, loggerLevel, logCaller)
+					b.Run(name, func(b *testing.B) {
+						b.Run("Logln", func(b *testing.B) {
+							b.ReportAllocs()
+							for i := 0; i < b.N; i++ {
+								l.Logln(level, "Availability updated.")
+							}
+						})
+						b.Run("Logf", func(b *testing.B) {
+							b.ReportAllocs()
+							for i := 0; i < b.N; i++ {
+								l.Logf(level, "Availability updated for %s.", lot.Name)
+							}
+						})
+						b.Run("Logd", func(b *testing.B) {
+							b.ReportAllocs()
+							for i := 0; i < b.N; i++ {
+								l.Logd(level, "Availability updated.", lot)
+							}
+						})
+						b.Run("Logw", func(b *testing.B) {
+							b.ReportAllocs()
+							for i := 0; i < b.N; i++ {
+								l.Logw(level, "Availability updated.", "lot", lot.Name, "spaces", lot.Spaces)
+							}
+						})
+						b.Run("Event", func(b *testing.B) {
+							b.ReportAllocs()
+							for i := 0; i < b.N; i++ {
+								benchmarkEvent(l, level).Str("lot", lot.Name).Int("spaces", lot.Spaces).Msg("Availability updated.")
+							}
+						})
+					})
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSinks(b *testing.B) {
+	l := NewLogger(LoggerConfig{
+		TimeFormat: TimeFormatLoggly,
+		LogCaller:  true,
+		Sinks: []Sink{
+			{Output: io.Discard, Level: LogLevelDebug, Format: LogFormatPretty, Colorize: true},
+			{Output: io.Discard, Level: LogLevelInfo, Format: LogFormatJSON},
+		},
+	})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Infod("Availability updated.", benchmarkLot{Name: "Lot A", Spaces: 120})
+	}
+}
@@ -0,0 +1,112 @@
+package log
+
+import (
+	"context"
+)
+
+// MARK: Types
+
+// contextKey is the type of the keys this package stores values in contexts
+// under
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota
+	fieldsContextKey
+	traceParentContextKey
+	requestIDContextKey
+)
+
+// MARK: Public Functions
+
+// NewContext returns a copy of ctx that carries the logger
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, l)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if it
+// doesn't carry one
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerContextKey).(Logger); ok {
+			return l
+		}
+	}
+	return LoggerSingleton
+}
+
+// ContextWithFields returns a copy of ctx that carries the fields in addition
+// to any it already carries. The *Ctx logging functions and methods log the
+// fields carried by their context.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	existing := ContextFields(ctx)
+	merged := make([]Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey, merged)
+}
+
+// ContextFields returns the fields carried by ctx
+func ContextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey).([]Field)
+	return fields
+}
+
+// LogCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values, with the logger carried by ctx
+func LogCtx(ctx context.Context, level Level, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, level, output, keysAndValues)
+}
+
+// TraceCtx prints the output string and fields with the logger carried by ctx
+func TraceCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, LogLevelTrace, output, keysAndValues)
+}
+
+// DebugCtx prints the output string and fields with the logger carried by ctx
+func DebugCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, LogLevelDebug, output, keysAndValues)
+}
+
+// InfoCtx prints the output string and fields with the logger carried by ctx
+func InfoCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, LogLevelInfo, output, keysAndValues)
+}
+
+// WarnCtx prints the output string and fields with the logger carried by ctx
+func WarnCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, LogLevelWarn, output, keysAndValues)
+}
+
+// ErrorCtx prints the output string and fields with the logger carried by ctx
+func ErrorCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	logContext(FromContext(ctx), ctx, LogLevelError, output, keysAndValues)
+}
+
+// FatalCtx prints the output string and fields with the logger carried by ctx
+// and calls os.Exit(1).
+func FatalCtx(ctx context.Context, output string, keysAndValues ...interface{}) {
+	l := FromContext(ctx)
+	logContext(l, ctx, LogLevelFatal, output, keysAndValues)
+	l.exit()
+}
+
+// MARK: Private Functions
+
+// logContext logs the message with the fields carried by ctx followed by
+// fields from alternating keys and values. It must be called directly by the
+// function called by the caller to log.
+func logContext(l Logger, ctx context.Context, level Level, message string, keysAndValues []interface{}) {
+	if l.level() > level {
+		return
+	}
+
+	contextFields := ContextFields(ctx)
+	fields := make([]Field, 0, len(contextFields)+(len(keysAndValues)+1)/2)
+	fields = append(fields, contextFields...)
+	fields = append(fields, keyValueFields(keysAndValues)...)
+	l.write(l.newLogMessage(message, level, directSkipOffset, fields))
+}
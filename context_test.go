@@ -0,0 +1,123 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	t.Run("FromContext", func(t *testing.T) {
+		l := NewLogger(LoggerConfig{Output: &syncBuffer{}})
+		if got := FromContext(NewContext(context.Background(), l)); got != l {
+			t.Error("expected the logger carried by the context")
+		}
+		if got := FromContext(context.Background()); got != LoggerSingleton {
+			t.Error("expected the default logger")
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		ctx := ContextWithFields(context.Background(), String("requestId", "abc"))
+		child := ContextWithFields(ctx, String("userId", "42"))
+		ContextWithFields(ctx, String("other", "x"))
+
+		if got := ContextFields(child); len(got) != 2 || got[0].Key != "requestId" || got[1].Key != "userId" {
+			t.Errorf("unexpected fields: %v", got)
+		}
+		if got := ContextFields(ctx); len(got) != 1 {
+			t.Errorf("parent fields changed: %v", got)
+		}
+	})
+
+	t.Run("Ctx Methods", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, LogCaller: true, Output: &buf})
+		ctx := ContextWithFields(context.Background(), String("requestId", "abc"))
+
+		l.InfoCtx(ctx, "logger", "lot", 7)
+		l.Sublogger("sub").WarnCtx(ctx, "sublogger")
+		l.WithField("a", 1).LogCtx(ctx, LogLevelError, "log")
+		l.TraceCtx(ctx, "disabled")
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		want := []string{
+			"message=logger requestId=abc lot=7",
+			"message=sublogger requestId=abc",
+			"message=log a=1 requestId=abc",
+		}
+		if len(lines) != len(want) {
+			t.Fatalf("unexpected lines: %q", lines)
+		}
+		for i, line := range lines {
+			if !strings.Contains(line, "file=context_test.go:") || !strings.HasSuffix(line, want[i]) {
+				t.Errorf("got %q, want suffix %q", line, want[i])
+			}
+		}
+	})
+
+	t.Run("Package Functions", func(t *testing.T) {
+		var def, carried syncBuffer
+		SetupLoggerWithConfig(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, LogCaller: true, Output: &def})
+		defer SetupLocalLogger(LogLevelDebug)
+
+		ctx := ContextWithFields(context.Background(), String("requestId", "abc"))
+		DebugCtx(ctx, "default")
+		ErrorCtx(ctx, "default")
+
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, LogCaller: true, Output: &carried})
+		ctx = NewContext(ctx, l.Sublogger("sub"))
+		InfoCtx(ctx, "carried", "lot", 7)
+		LogCtx(ctx, LogLevelWarn, "carried")
+
+		for _, buf := range []*syncBuffer{&def, &carried} {
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("unexpected lines: %q", lines)
+			}
+			for _, line := range lines {
+				if !strings.Contains(line, "file=context_test.go:") || !strings.Contains(line, "requestId=abc") {
+					t.Errorf("unexpected line %q", line)
+				}
+			}
+		}
+		if !strings.Contains(carried.String(), "tags=sub") {
+			t.Errorf("expected the carried logger's tags, got %q", carried.String())
+		}
+	})
+
+	t.Run("Request Logger", func(t *testing.T) {
+		var buf syncBuffer
+		rl := NewRequestLogger(RequestLoggerConfig{
+			Logger: NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf}),
+			Tags:   []string{"requests"},
+		})
+
+		h := rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			InfoCtx(r.Context(), "handling")
+		}))
+		for i := 0; i < 2; i++ {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/lots", nil))
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("expected two lines per request, got %q", lines)
+		}
+		var entry struct {
+			Tags    []string
+			Message string
+			File    string
+		}
+		if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", lines[0], err)
+		}
+		if entry.Message != "handling" || len(entry.Tags) != 1 || entry.Tags[0] != "requests" ||
+			!strings.HasPrefix(entry.File, "context_test.go:") {
+			t.Errorf("unexpected handler log: %+v", entry)
+		}
+	})
+}
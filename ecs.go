@@ -0,0 +1,112 @@
+package log
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ecsVersion is the version of Elastic Common Schema that ECS output conforms
+// to
+const ecsVersion = "8.11.0"
+
+// MARK: Methods
+
+// appendECS appends the message as JSON with Elastic Common Schema fields.
+// Metadata is nested under the configured namespace.
+func (m logMessage) appendECS(dst []byte) []byte {
+	// keys are the keys of the entry, so that fields with the same keys are
+	// skipped
+	keys := make([]string, 0, 16)
+	key := func(k string) {
+		dst = appendJSONKey(dst, k)
+		keys = append(keys, k)
+	}
+	has := func(k string) bool {
+		for _, written := range keys {
+			if written == k {
+				return true
+			}
+		}
+		return false
+	}
+
+	dst = append(dst, '{')
+
+	key("@timestamp")
+	dst = append(dst, '"')
+	dst = m.time.UTC().AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, '"')
+	key("log.level")
+	dst = append(dst, '"')
+	for i := 0; i < len(m.Level); i++ {
+		c := m.Level[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	dst = append(dst, '"')
+	key("message")
+	dst = appendJSONString(dst, m.Message)
+	key("ecs.version")
+	dst = appendJSONString(dst, ecsVersion)
+	if len(m.Tags) > 0 {
+		key("tags")
+		dst = appendJSONStrings(dst, m.Tags)
+	}
+
+	if m.logCaller {
+		file, line := m.File, ""
+		if i := strings.LastIndex(m.File, ":"); i >= 0 {
+			file, line = m.File[:i], m.File[i+1:]
+		}
+		key("log.origin.file.name")
+		dst = appendJSONString(dst, file)
+		if n, err := strconv.Atoi(line); err == nil {
+			key("log.origin.file.line")
+			dst = strconv.AppendInt(dst, int64(n), 10)
+		}
+	}
+
+	if rl, ok := m.Metadata.(requestLog); ok {
+		key("http.request.method")
+		dst = appendJSONString(dst, rl.method)
+		key("url.path")
+		dst = appendJSONString(dst, rl.path)
+		key("url.original")
+		dst = appendJSONString(dst, rl.url)
+		key("event.duration")
+		dst = strconv.AppendInt(dst, rl.latency.Nanoseconds(), 10)
+		if rl.status != 0 {
+			key("http.response.status_code")
+			dst = strconv.AppendInt(dst, int64(rl.status), 10)
+		}
+		if rl.userAgent != "" {
+			key("user_agent.original")
+			dst = appendJSONString(dst, rl.userAgent)
+		}
+		if rl.remoteIP != "" {
+			key("client.ip")
+			dst = appendJSONString(dst, rl.remoteIP)
+		}
+		if v := strings.TrimPrefix(rl.protocol, "HTTP/"); v != rl.protocol {
+			key("http.version")
+			dst = appendJSONString(dst, v)
+		}
+	}
+
+	if m.Metadata != nil {
+		namespace := m.options.ecsNamespace
+		if namespace == "" {
+			namespace = "metadata"
+		}
+		if !has(namespace) {
+			key(namespace)
+			dst = appendJSONValue(dst, m.Metadata)
+		}
+	}
+
+	dst = appendJSONFields(dst, m.allFields(), has)
+	return append(dst, '}')
+}
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestECS(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:        LogLevelDebug,
+			Format:       LogFormatECS,
+			LogCaller:    true,
+			Tags:         []string{"some-api"},
+			Output:       &buf,
+			ECSNamespace: "parkhub",
+		})
+		l.Warnd("This is a warning.", map[string]int{"spaces": 120})
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["log.level"] != "warn" || entry["message"] != "This is a warning." {
+			t.Errorf("unexpected level or message: %v", entry)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, entry["@timestamp"].(string)); err != nil {
+			t.Errorf("invalid @timestamp: %v", err)
+		}
+		if tags, _ := entry["tags"].([]interface{}); len(tags) != 1 || tags[0] != "some-api" {
+			t.Errorf("unexpected tags: %v", entry["tags"])
+		}
+		if entry["log.origin.file.name"] != "ecs_test.go" {
+			t.Errorf("unexpected file name: %v", entry["log.origin.file.name"])
+		}
+		if line, _ := entry["log.origin.file.line"].(float64); line <= 0 {
+			t.Errorf("unexpected file line: %v", entry["log.origin.file.line"])
+		}
+		if md, _ := entry["parkhub"].(map[string]interface{}); md["spaces"] != 120.0 {
+			t.Errorf("unexpected metadata: %v", entry["parkhub"])
+		}
+		if _, ok := entry["metadata"]; ok {
+			t.Error("metadata not nested under namespace")
+		}
+	})
+
+	t.Run("Request Log", func(t *testing.T) {
+		var buf syncBuffer
+		rl := NewRequestLogger(RequestLoggerConfig{
+			Logger: NewLogger(LoggerConfig{
+				Level:  LogLevelDebug,
+				Format: LogFormatECS,
+				Output: &buf,
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/lots?id=1", nil)
+		rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})).ServeHTTP(httptest.NewRecorder(), req)
+
+		deadline := time.Now().Add(time.Second)
+		for buf.String() == "" {
+			if time.Now().After(deadline) {
+				t.Fatal("request was not logged")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["http.request.method"] != "POST" || entry["url.path"] != "/lots" ||
+			entry["http.response.status_code"] != 201.0 {
+			t.Errorf("unexpected request fields: %v", entry)
+		}
+		if _, ok := entry["event.duration"].(float64); !ok {
+			t.Errorf("unexpected event.duration: %v", entry["event.duration"])
+		}
+		if _, ok := entry["metadata"]; !ok {
+			t.Error("expected metadata")
+		}
+	})
+}
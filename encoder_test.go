@@ -0,0 +1,97 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAppendJSON(t *testing.T) {
+	t.Run("Strings", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"plain",
+			"quote \" backslash \\ slash /",
+			"html <b>&amp;</b>",
+			"control \n\r\t\b\f\x00\x1f\x7f",
+			"unicode é 世界    ",
+			"invalid \xff\xfe utf-8",
+		} {
+			want, _ := json.Marshal(s)
+			if got := appendJSONString(nil, s); !jsonEqual(got, want) {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		}
+
+		if got, want := string(appendJSONString(nil, "<&> \u2028")), `"\u003c\u0026\u003e \u2028"`; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		for _, v := range []interface{}{
+			nil, true, false,
+			0, -5, int8(-8), int16(16), int32(-32), int64(math.MaxInt64),
+			uint(5), uint8(8), uint16(16), uint32(32), uint64(math.MaxUint64),
+			0.0, 1.5, -2.25, 1e-7, 1e21, 123456789.0, float32(0.1), float32(1e-7),
+			[]string{"a", "<b>"}, []string(nil),
+			time.Date(2021, 2, 27, 18, 8, 48, 749400123, time.UTC),
+			map[string]int{"b": 2, "a": 1},
+			struct {
+				Name string `json:"name"`
+			}{"Lot A"},
+		} {
+			want, _ := json.Marshal(v)
+			if got := appendJSONValue(nil, v); string(got) != string(want) {
+				t.Errorf("%T: got %s, want %s", v, got, want)
+			}
+		}
+	})
+
+	t.Run("Unmarshalable", func(t *testing.T) {
+		if got := string(appendJSONValue(nil, math.NaN())); got != `"NaN"` {
+			t.Errorf("got %s", got)
+		}
+		if got := string(appendJSONValue(nil, func() {})); got[0] != '"' {
+			t.Errorf("expected string, got %s", got)
+		}
+	})
+
+	t.Run("Message", func(t *testing.T) {
+		type reference struct {
+			Timestamp string      `json:"timestamp"`
+			Level     string      `json:"level"`
+			Tags      []string    `json:"tags"`
+			Message   string      `json:"message"`
+			Metadata  interface{} `json:"metadata,omitempty"`
+			File      string      `json:"file,omitempty"`
+		}
+
+		for _, d := range []interface{}{
+			nil,
+			"details",
+			map[string]interface{}{"spaces": 120, "name": "Lot <A>"},
+			errors.New("connection refused"),
+			[]interface{}{errors.New("first"), 2},
+		} {
+			m := newLogMessage(LogFormatJSON, false, true, 0, time.Now(), TimeFormatLoggly,
+				LogLevelInfo, []string{"a", "b"}, "  hello \"world\"\n", d)
+			want, _ := json.Marshal(reference{m.Timestamp, m.Level, m.Tags, m.Message, m.Metadata, m.File})
+			if got := m.appendJSON(nil); string(got) != string(want) {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		}
+	})
+}
+
+// jsonEqual reports whether two JSON strings decode to the same string, since
+// encoding/json escapes some control characters differently between versions
+func jsonEqual(a, b []byte) bool {
+	var sa, sb string
+	if json.Unmarshal(a, &sa) != nil || json.Unmarshal(b, &sb) != nil {
+		return false
+	}
+	return sa == sb
+}
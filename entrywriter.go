@@ -0,0 +1,55 @@
+package log
+
+// MARK: Types
+
+// EntryWriter writes entries logged by a Logger returned by NewEntryLogger.
+type EntryWriter interface {
+	// WriteEntry writes the entry. The entry's slices are not reused by the
+	// logger, so they may be kept.
+	WriteEntry(e Entry)
+}
+
+// EntryWriterFunc is an adapter to allow the use of ordinary functions as
+// entry writers.
+type EntryWriterFunc func(e Entry)
+
+// WriteEntry calls f(e).
+func (f EntryWriterFunc) WriteEntry(e Entry) {
+	f(e)
+}
+
+// entryWriter is a messageWriter that writes messages as entries
+type entryWriter struct {
+	writer EntryWriter
+}
+
+// MARK: Public Functions
+
+// NewEntryLogger returns a Logger that writes messages as entries to the
+// EntryWriter instead of formatting them, such as to record them in tests. The
+// level, time format and location, tags and whether to log the caller are
+// read from the config, and its outputs and formats are ignored. Messages at
+// the fatal level are written without exiting.
+func NewEntryLogger(w EntryWriter, config LoggerConfig) Logger {
+	config.Output = nil
+	config.Async = nil
+	config.Sinks = nil
+	l := newLogger(config)
+	l.messages = entryWriter{writer: w}
+	l.exitFunc = func() {}
+	return l
+}
+
+// MARK: Private Methods
+
+// writeMessage writes the message as an entry with copies of its slices
+func (w entryWriter) writeMessage(m *logMessage) {
+	e := m.entry()
+	if e.Tags != nil {
+		e.Tags = append([]string(nil), e.Tags...)
+	}
+	if e.Fields != nil {
+		e.Fields = append([]Field(nil), e.Fields...)
+	}
+	w.writer.WriteEntry(e)
+}
@@ -0,0 +1,35 @@
+package log
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEntryLogger(t *testing.T) {
+	var entries []Entry
+	l := NewEntryLogger(EntryWriterFunc(func(e Entry) {
+		entries = append(entries, e)
+	}), LoggerConfig{Level: LogLevelInfo, LogCaller: true, Tags: []string{"api"}})
+
+	l.Debugln("dropped")
+	l.Sublogger("lots").Info().Str("lot", "7").Msg("Lot opened.")
+	l.Info().Str("lot", "8").Msg("Lot opened.")
+	l.Fatalln("Lot on fire.")
+
+	if len(entries) != 3 {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	e := entries[0]
+	if e.Level != LogLevelInfo || e.Message != "Lot opened." || !reflect.DeepEqual(e.Tags, []string{"api", "lots"}) ||
+		!strings.HasPrefix(e.File, "entrywriter_test.go:") {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	// the event's fields are reused by the second event
+	if !reflect.DeepEqual(e.Fields, []Field{String("lot", "7")}) {
+		t.Errorf("expected a copy of the fields, got %+v", e.Fields)
+	}
+	if entries[2].Level != LogLevelFatal {
+		t.Errorf("expected a fatal entry, got %+v", entries[2])
+	}
+}
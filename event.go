@@ -0,0 +1,221 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MARK: Types
+
+// Event is a log message being built with fields, started by one of the level
+// methods such as Logger.Info and written by Msg, Msgf or Send. An Event for a
+// level the logger doesn't log is nil, and all of its methods do nothing, so
+// adding fields costs nothing. An Event must not be used after it is written.
+type Event struct {
+	logger Logger
+	level  Level
+	fields []Field
+}
+
+// eventPool reuses events and their fields between messages
+var eventPool = sync.Pool{
+	New: func() interface{} {
+		return &Event{fields: make([]Field, 0, 8)}
+	},
+}
+
+// MARK: Public Functions
+
+// Trace starts a trace level event on the default logger
+func Trace() *Event {
+	return LoggerSingleton.Trace()
+}
+
+// Debug starts a debug level event on the default logger
+func Debug() *Event {
+	return LoggerSingleton.Debug()
+}
+
+// Info starts an info level event on the default logger
+func Info() *Event {
+	return LoggerSingleton.Info()
+}
+
+// Warn starts a warn level event on the default logger
+func Warn() *Event {
+	return LoggerSingleton.Warn()
+}
+
+// Error starts an error level event on the default logger
+func Error() *Event {
+	return LoggerSingleton.Error()
+}
+
+// Fatal starts a fatal level event on the default logger. Writing the event
+// calls os.Exit(1).
+func Fatal() *Event {
+	return LoggerSingleton.Fatal()
+}
+
+// MARK: Methods
+
+// Str adds a string field to the event
+func (e *Event) Str(key, value string) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Int adds an int field to the event
+func (e *Event) Int(key string, value int) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Int64 adds an int64 field to the event
+func (e *Event) Int64(key string, value int64) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Float64 adds a float64 field to the event
+func (e *Event) Float64(key string, value float64) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Bool adds a bool field to the event
+func (e *Event) Bool(key string, value bool) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Dur adds a time.Duration field to the event, written as a string such as
+// "1.5s"
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Duration(key, value))
+	return e
+}
+
+// Time adds a time.Time field to the event
+func (e *Event) Time(key string, value time.Time) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Err adds the error's message to the event under the key "error". A nil
+// error adds nothing.
+func (e *Event) Err(err error) *Event {
+	if e == nil || err == nil {
+		return e
+	}
+	e.fields = append(e.fields, Err(err))
+	return e
+}
+
+// Any adds a field with any value to the event
+func (e *Event) Any(key string, value interface{}) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Fields adds fields to the event
+func (e *Event) Fields(fields ...Field) *Event {
+	if e == nil {
+		return e
+	}
+	e.fields = append(e.fields, fields...)
+	return e
+}
+
+// Enabled reports whether the event will be written
+func (e *Event) Enabled() bool {
+	return e != nil
+}
+
+// Msg writes the event with the message
+func (e *Event) Msg(message string) {
+	if e == nil {
+		return
+	}
+	e.write(message)
+}
+
+// Msgf writes the event with the formatted message
+func (e *Event) Msgf(format string, a ...interface{}) {
+	if e == nil {
+		return
+	}
+	e.write(fmt.Sprintf(format, a...))
+}
+
+// Send writes the event with an empty message
+func (e *Event) Send() {
+	if e == nil {
+		return
+	}
+	e.write("")
+}
+
+// MARK: Private Functions
+
+// newEvent returns an event for the logger, or nil if the logger doesn't log
+// the level
+func newEvent(l Logger, level Level) *Event {
+	if l.level() > level {
+		return nil
+	}
+
+	e := eventPool.Get().(*Event)
+	e.logger = l
+	e.level = level
+	return e
+}
+
+// directSkipOffset is the skip offset for methods that call a function that
+// calls newLogMessage, like Msg calling write. They reach newLogMessage in one
+// frame instead of the two of the base log implementations and printMessage.
+const directSkipOffset = -1
+
+// MARK: Private Methods
+
+// write writes the event and returns it to the pool, exiting if it is fatal
+func (e *Event) write(message string) {
+	l, level := e.logger, e.level
+	l.write(l.newLogMessage(message, level, directSkipOffset, e.fields))
+
+	e.logger = nil
+	for i := range e.fields {
+		e.fields[i] = Field{}
+	}
+	e.fields = e.fields[:0]
+	eventPool.Put(e)
+
+	if level == LogLevelFatal {
+		l.exit()
+	}
+}
@@ -0,0 +1,109 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvent(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf})
+		l.WithField("orgId", "123").Info().
+			Str("lot", "A").
+			Int("spaces", 120).
+			Int64("total", 1<<40).
+			Float64("rate", 0.5).
+			Bool("full", false).
+			Dur("took", 1500*time.Millisecond).
+			Err(errors.New("oops")).
+			Err(nil).
+			Any("gates", []string{"north"}).
+			Fields(String("zone", "east")).
+			Msgf("lot %s", "opened")
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["message"] != "lot opened" || entry["level"] != "INFO" || entry["orgId"] != "123" ||
+			entry["lot"] != "A" || entry["spaces"] != 120.0 || entry["total"] != float64(1<<40) ||
+			entry["rate"] != 0.5 || entry["full"] != false || entry["took"] != "1.5s" ||
+			entry["error"] != "oops" || entry["zone"] != "east" {
+			t.Errorf("unexpected entry: %v", entry)
+		}
+	})
+
+	t.Run("Pooled Events Reset", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, Output: &buf})
+		l.Info().Str("a", "1").Msg("first")
+		l.Info().Str("b", "2").Send()
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 || !strings.HasSuffix(lines[0], "message=first a=1") ||
+			!strings.HasSuffix(lines[1], `message="" b=2`) {
+			t.Errorf("unexpected lines: %q", lines)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelWarn, Output: &buf})
+		if e := l.Info(); e != nil || e.Enabled() {
+			t.Fatal("expected nil event for disabled level")
+		}
+
+		err := errors.New("oops")
+		allocs := testing.AllocsPerRun(100, func() {
+			l.Info().Str("lot", "A").Int("spaces", 120).Err(err).Msg("skipped")
+		})
+		if allocs != 0 {
+			t.Errorf("expected no allocations, got %v", allocs)
+		}
+		if buf.String() != "" {
+			t.Errorf("unexpected output %q", buf.String())
+		}
+	})
+
+	t.Run("Caller", func(t *testing.T) {
+		var buf syncBuffer
+		SetupLoggerWithConfig(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf})
+		defer SetupLocalLogger(LogLevelDebug)
+
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf})
+		l.Info().Msg("logger")
+		l.Sublogger("a").Info().Msg("sublogger")
+		l.Sublogger("a").Sublogger("b").WithField("c", 1).Info().Msg("nested")
+		Info().Msg("package")
+		Sublogger("a").Info().Msg("package sublogger")
+		With(Int("c", 1)).Info().Msg("package with")
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 6 {
+			t.Fatalf("unexpected lines: %q", lines)
+		}
+		caller := regexp.MustCompile(`"file":"event_test.go:\d+"`)
+		for _, line := range lines {
+			if !caller.MatchString(line) {
+				t.Errorf("unexpected caller: %s", line)
+			}
+		}
+	})
+
+	t.Run("Fatal", func(t *testing.T) {
+		var buf syncBuffer
+		l := newLogger(LoggerConfig{Level: LogLevelDebug, Output: &buf})
+		exited := false
+		l.exitFunc = func() { exited = true }
+		l.Fatal().Str("a", "1").Msg("fatal")
+
+		if !exited || !strings.Contains(buf.String(), "[FATAL] fatal a=1") {
+			t.Errorf("expected fatal log and exit, got %q, exited %v", buf.String(), exited)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package log
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// MARK: Types
+
+// FieldNames configures the keys and order of fields in LogFormatJSON output.
+// Empty names keep the default key.
+type FieldNames struct {
+	Timestamp string
+	Level     string
+	Tags      string
+	Message   string
+	Metadata  string
+	File      string
+
+	// Order is the order fields are written in, listed by their default keys:
+	// "timestamp", "level", "tags", "message", "metadata" and "file". Fields
+	// that aren't listed are written after, in the default order.
+	Order []string
+
+	// InlineMetadata writes the keys of metadata that marshals to a JSON object
+	// at the top level instead of nesting them under the metadata key. Keys that
+	// collide with another field or a logger field are dropped. Other metadata
+	// is still nested.
+	InlineMetadata bool
+}
+
+// defaultFieldOrder is the default order of fields, by their default keys
+var defaultFieldOrder = []string{"timestamp", "level", "tags", "message", "metadata", "file"}
+
+// jsonField is a field of JSON output, identified by its default key
+type jsonField struct {
+	id  string
+	key string
+}
+
+// jsonLayout is the resolved keys and order of JSON output fields
+type jsonLayout struct {
+	fields         []jsonField
+	reserved       map[string]bool
+	inlineMetadata bool
+}
+
+// MARK: Private Functions
+
+// newJSONLayout returns the layout for the field names, or nil if they don't
+// change the default output
+func newJSONLayout(names FieldNames) *jsonLayout {
+	keys := map[string]string{
+		"timestamp": names.Timestamp,
+		"level":     names.Level,
+		"tags":      names.Tags,
+		"message":   names.Message,
+		"metadata":  names.Metadata,
+		"file":      names.File,
+	}
+
+	custom := len(names.Order) > 0 || names.InlineMetadata
+	for id, key := range keys {
+		if key == "" {
+			keys[id] = id
+		} else if key != id {
+			custom = true
+		}
+	}
+	if !custom {
+		return nil
+	}
+
+	layout := &jsonLayout{reserved: map[string]bool{}, inlineMetadata: names.InlineMetadata}
+	added := map[string]bool{}
+	for _, id := range append(append([]string{}, names.Order...), defaultFieldOrder...) {
+		if key, ok := keys[id]; ok && !added[id] {
+			layout.fields = append(layout.fields, jsonField{id: id, key: key})
+			layout.reserved[key] = true
+			added[id] = true
+		}
+	}
+	return layout
+}
+
+// MARK: Methods
+
+// appendJSONLayout appends the message as JSON with the keys and order of a
+// layout
+func (m logMessage) appendJSONLayout(dst []byte, layout *jsonLayout) []byte {
+	fields := m.allFields()
+	dst = append(dst, '{')
+	for _, f := range layout.fields {
+		switch f.id {
+		case "timestamp":
+			dst = appendJSONKey(dst, f.key)
+			if m.timeFormat.numeric() {
+				dst = append(dst, m.Timestamp...)
+			} else {
+				dst = appendJSONString(dst, m.Timestamp)
+			}
+		case "level":
+			dst = appendJSONKey(dst, f.key)
+			dst = appendJSONString(dst, m.Level)
+		case "tags":
+			dst = appendJSONKey(dst, f.key)
+			dst = appendJSONStrings(dst, m.Tags)
+		case "message":
+			dst = appendJSONKey(dst, f.key)
+			dst = appendJSONString(dst, m.Message)
+		case "metadata":
+			if m.Metadata == nil {
+				continue
+			}
+			start := len(dst)
+			dst = appendJSONKey(dst, f.key)
+			value := len(dst)
+			dst = appendJSONValue(dst, m.Metadata)
+			if layout.inlineMetadata && dst[value] == '{' {
+				dst = inlineJSONMetadata(dst, start, value, layout, fields)
+			}
+		case "file":
+			if m.File != "" {
+				dst = appendJSONKey(dst, f.key)
+				dst = appendJSONString(dst, m.File)
+			}
+		}
+	}
+
+	dst = appendJSONFields(dst, fields, func(key string) bool {
+		return layout.reserved[key]
+	})
+	return append(dst, '}')
+}
+
+// MARK: Private Functions
+
+// inlineJSONMetadata replaces the metadata key and object appended to dst at
+// start, with its value at value, with the object's keys, sorted and without
+// the keys of the layout or fields
+func inlineJSONMetadata(dst []byte, start, value int, layout *jsonLayout, fields []Field) []byte {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(dst[value:], &object); err != nil {
+		return dst
+	}
+
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		if !layout.reserved[k] && !hasField(fields, k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	dst = dst[:start]
+	for _, k := range keys {
+		dst = appendJSONKey(dst, k)
+		dst = append(dst, object[k]...)
+	}
+	return dst
+}
+
+// hasField reports whether any of the fields has the key
+func hasField(fields []Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
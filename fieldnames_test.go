@@ -0,0 +1,77 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFieldNames(t *testing.T) {
+	type lot struct {
+		Name    string `json:"name"`
+		Spaces  int    `json:"spaces"`
+		Message string `json:"msg"`
+	}
+
+	tests := []struct {
+		name       string
+		fieldNames FieldNames
+		metadata   interface{}
+		want       string
+	}{
+		{
+			name:     "Default",
+			metadata: 5,
+			want:     `^{"timestamp":"[^"]+","level":"INFO","tags":\["a"\],"message":"hello","metadata":5,"file":"fieldnames_test.go:\d+"}$`,
+		},
+		{
+			name: "Renamed",
+			fieldNames: FieldNames{
+				Timestamp: "ts",
+				Level:     "lvl",
+				Message:   "msg",
+				File:      "caller",
+				Metadata:  "data",
+			},
+			metadata: 5,
+			want:     `^{"ts":"[^"]+","lvl":"INFO","tags":\["a"\],"msg":"hello","data":5,"caller":"fieldnames_test.go:\d+"}$`,
+		},
+		{
+			name:       "Ordered",
+			fieldNames: FieldNames{Order: []string{"message", "level", "unknown", "level"}},
+			want:       `^{"message":"hello","level":"INFO","timestamp":"[^"]+","tags":\["a"\],"file":"fieldnames_test.go:\d+"}$`,
+		},
+		{
+			name:       "Inline Metadata",
+			fieldNames: FieldNames{Message: "msg", InlineMetadata: true},
+			metadata:   lot{Name: "Lot A", Spaces: 120, Message: "dropped"},
+			want:       `^{"timestamp":"[^"]+","level":"INFO","tags":\["a"\],"msg":"hello","name":"Lot A","spaces":120,"file":"fieldnames_test.go:\d+"}$`,
+		},
+		{
+			name:       "Inline Scalar Metadata",
+			fieldNames: FieldNames{InlineMetadata: true},
+			metadata:   "details",
+			want:       `"message":"hello","metadata":"details",`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf syncBuffer
+			l := NewLogger(LoggerConfig{
+				Level:      LogLevelDebug,
+				Format:     LogFormatJSON,
+				LogCaller:  true,
+				Tags:       []string{"a"},
+				Output:     &buf,
+				FieldNames: tt.fieldNames,
+			})
+			l.Infod("hello", tt.metadata)
+
+			got := strings.TrimSuffix(buf.String(), "\n")
+			if !regexp.MustCompile(tt.want).MatchString(got) {
+				t.Errorf("got %s, want match for %s", got, tt.want)
+			}
+		})
+	}
+}
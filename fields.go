@@ -0,0 +1,237 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MARK: Types
+
+// Field is a key and value logged with a message. JSON formats write fields as
+// top-level keys, and the pretty format writes them as key=value pairs after
+// the message. A Field or []Field passed as the data of a log call is logged as
+// fields of that message instead of as metadata.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// MARK: Public Functions
+
+// String returns a Field with a string value
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int returns a Field with an int value
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 returns a Field with an int64 value
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 returns a Field with a float64 value
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool returns a Field with a bool value
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration returns a Field with a time.Duration value, written as a string
+// such as "1.5s"
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value.String()}
+}
+
+// Time returns a Field with a time.Time value
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err returns a Field with the key "error" and the error's message
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+	return Field{Key: "error", Value: err.Error()}
+}
+
+// Any returns a Field with any value
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// With returns a logger on the default logger that logs the provided fields
+// with every message
+func With(fields ...Field) Logger {
+	return &sublogger{
+		Logger:     LoggerSingleton,
+		fields:     fields,
+		skipOffset: 1,
+	}
+}
+
+// WithField returns a logger on the default logger that logs the provided key
+// and value with every message
+func WithField(key string, value interface{}) Logger {
+	return With(Field{Key: key, Value: value})
+}
+
+// MARK: Private Functions
+
+// callFields returns the fields in the data of a log call, if the data is a
+// Field or []Field
+func callFields(d interface{}) ([]Field, bool) {
+	switch v := d.(type) {
+	case Field:
+		return []Field{v}, true
+	case []Field:
+		return v, true
+	}
+	return nil, false
+}
+
+// badKey is the key of values in keysAndValues that don't follow a string key
+const badKey = "!BADKEY"
+
+// keyValueFields returns fields from alternating string keys and values. A
+// Field in place of a key is used as is. A value without a key, such as a
+// final key with no value or a key that isn't a string, is logged under
+// "!BADKEY".
+func keyValueFields(keysAndValues []interface{}) []Field {
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i++ {
+		switch k := keysAndValues[i].(type) {
+		case Field:
+			fields = append(fields, k)
+		case string:
+			if i+1 == len(keysAndValues) {
+				fields = append(fields, Field{Key: badKey, Value: k})
+				continue
+			}
+			fields = append(fields, Field{Key: k, Value: keysAndValues[i+1]})
+			i++
+		default:
+			fields = append(fields, Field{Key: badKey, Value: k})
+		}
+	}
+	return fields
+}
+
+// mergeFields returns the fields with later fields replacing the values of
+// earlier fields with the same key. Fields under badKey are all kept. Fields
+// without duplicate keys are returned as is.
+func mergeFields(fields []Field) []Field {
+	if !hasDuplicateKeys(fields) {
+		return fields
+	}
+
+	merged := make([]Field, 0, len(fields))
+	index := make(map[string]int, len(fields))
+	for _, f := range fields {
+		if i, ok := index[f.Key]; ok && f.Key != badKey {
+			merged[i].Value = f.Value
+			continue
+		}
+		index[f.Key] = len(merged)
+		merged = append(merged, f)
+	}
+	return merged
+}
+
+// hasDuplicateKeys reports whether any two fields, other than those under
+// badKey, have the same key. Small numbers of fields are compared directly to
+// avoid allocating a map.
+func hasDuplicateKeys(fields []Field) bool {
+	if len(fields) < 2 {
+		return false
+	}
+
+	if len(fields) <= 16 {
+		for i := 1; i < len(fields); i++ {
+			for j := 0; j < i; j++ {
+				if fields[i].Key == fields[j].Key && fields[i].Key != badKey {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if _, ok := seen[f.Key]; ok && f.Key != badKey {
+			return true
+		}
+		seen[f.Key] = struct{}{}
+	}
+	return false
+}
+
+// fieldValue returns a field value that marshals usefully, converting errors
+// that don't implement json.Marshaler to their message
+func fieldValue(v interface{}) interface{} {
+	if e, ok := v.(error); ok {
+		if _, ok := e.(json.Marshaler); !ok {
+			return e.Error()
+		}
+	}
+	return v
+}
+
+// appendJSONFields appends fields as keys of the JSON object being appended to
+// dst, skipping fields whose key is reserved
+func appendJSONFields(dst []byte, fields []Field, reserved func(key string) bool) []byte {
+	for _, f := range fields {
+		if !reserved(f.Key) {
+			dst = appendJSONKey(dst, f.Key)
+			dst = appendJSONValue(dst, fieldValue(f.Value))
+		}
+	}
+	return dst
+}
+
+// appendPrettyFields appends fields as space separated key=value pairs
+func appendPrettyFields(dst []byte, fields []Field) []byte {
+	for i, f := range fields {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = appendLogfmtKey(dst, f.Key)
+		dst = append(dst, '=')
+
+		switch v := fieldValue(f.Value).(type) {
+		case string:
+			dst = appendLogfmtString(dst, v)
+		case int:
+			dst = strconv.AppendInt(dst, int64(v), 10)
+		case int64:
+			dst = strconv.AppendInt(dst, v, 10)
+		case bool:
+			dst = strconv.AppendBool(dst, v)
+		default:
+			dst = appendLogfmtString(dst, fmt.Sprint(v))
+		}
+	}
+	return dst
+}
+
+// MARK: Methods
+
+// allFields returns the logger's fields merged with the fields of the call
+func (m logMessage) allFields() []Field {
+	if len(m.callFields) == 0 {
+		return mergeFields(m.fields)
+	}
+	fields := make([]Field, 0, len(m.fields)+len(m.callFields))
+	fields = append(fields, m.fields...)
+	return mergeFields(append(fields, m.callFields...))
+}
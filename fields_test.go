@@ -0,0 +1,149 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf})
+		l.With(String("orgId", "123"), Int("lot", 7)).
+			WithField("gate", "north").
+			Infod("opened", []Field{Int("lot", 8), Bool("full", false), Err(errors.New("oops"))})
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["orgId"] != "123" || entry["lot"] != 8.0 || entry["gate"] != "north" ||
+			entry["full"] != false || entry["error"] != "oops" {
+			t.Errorf("unexpected fields: %v", entry)
+		}
+		if _, ok := entry["metadata"]; ok {
+			t.Errorf("fields logged as metadata: %v", entry)
+		}
+		if !strings.Contains(buf.String(), `"orgId":"123","lot":8,"gate":"north","full":false`) {
+			t.Errorf("unexpected field order: %s", buf.String())
+		}
+	})
+
+	t.Run("Reserved Keys", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf})
+		l.WithField("message", "overridden").Infod("kept", 5)
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["message"] != "kept" || entry["metadata"] != 5.0 {
+			t.Errorf("unexpected entry: %v", entry)
+		}
+	})
+
+	t.Run("Pretty", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatPretty, Output: &buf})
+		l.Sublogger("sub").With(String("orgId", "123"), Duration("took", 1500*time.Millisecond)).
+			Infod("opened", Field{Key: "name", Value: "Lot A"})
+
+		if want := "(sub) opened orgId=123 took=1.5s name=\"Lot A\" \n"; !strings.HasSuffix(buf.String(), want) {
+			t.Errorf("got %q, want suffix %q", buf.String(), want)
+		}
+	})
+
+	t.Run("Logfmt", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, Output: &buf})
+		l.WithField("orgId", 123).Infod("opened", "data")
+
+		if want := "message=opened orgId=123 metadata=data\n"; !strings.HasSuffix(buf.String(), want) {
+			t.Errorf("got %q, want suffix %q", buf.String(), want)
+		}
+	})
+
+	t.Run("Independent Children", func(t *testing.T) {
+		var buf syncBuffer
+		parent := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatLogfmt, Output: &buf}).
+			WithField("a", 1)
+		parent.WithField("b", 2).Infoln("first")
+		parent.WithField("c", 3).Infoln("second")
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 || !strings.HasSuffix(lines[0], "a=1 b=2") || !strings.HasSuffix(lines[1], "a=1 c=3") {
+			t.Errorf("unexpected lines: %q", lines)
+		}
+	})
+
+	t.Run("Caller", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf})
+		l.WithField("a", 1).WithField("b", 2).Infoln("nested")
+		l.Sublogger("s").WithField("a", 1).Infoln("nested")
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if !strings.Contains(line, `"file":"fields_test.go:`) {
+				t.Errorf("unexpected caller: %s", line)
+			}
+		}
+	})
+}
+
+func TestKeyValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		keysAndValues []interface{}
+		want          string
+	}{
+		{"Pairs", []interface{}{"orgId", 123, "gate", "north"}, `"orgId":123,"gate":"north"`},
+		{"Field", []interface{}{"orgId", 123, Bool("full", true)}, `"orgId":123,"full":true`},
+		{"Missing Value", []interface{}{"orgId", 123, "gate"}, `"orgId":123,"!BADKEY":"gate"`},
+		{"Non-String Key", []interface{}{5, "orgId", 123, true}, `"!BADKEY":5,"orgId":123,"!BADKEY":true`},
+		{"Empty", nil, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf syncBuffer
+			l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf})
+			l.Infow("hello", tt.keysAndValues...)
+
+			want := `"message":"hello","file":"fields_test.go:\d+"`
+			if tt.want != "" {
+				want += "," + regexp.QuoteMeta(tt.want)
+			}
+			if got := strings.TrimSpace(buf.String()); !regexp.MustCompile(want + "}$").MatchString(got) {
+				t.Errorf("got %s, want match for %s", got, want)
+			}
+		})
+	}
+
+	t.Run("Levels", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelTrace, Format: LogFormatLogfmt, Output: &buf})
+		l.Tracew("t", "k", 1)
+		l.Debugw("d", "k", 2)
+		l.Infow("i", "k", 3)
+		l.Warnw("w", "k", 4)
+		l.Errorw("e", "k", 5)
+		l.Logw(LogLevelInfo, "l", "k", 6)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		want := []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "INFO"}
+		if len(lines) != len(want) {
+			t.Fatalf("unexpected lines: %q", lines)
+		}
+		for i, line := range lines {
+			if !strings.Contains(line, "level="+want[i]) || !strings.HasSuffix(line, fmt.Sprintf("k=%d", i+1)) {
+				t.Errorf("unexpected line %q", line)
+			}
+		}
+	})
+}
@@ -0,0 +1,356 @@
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// MARK: Types
+
+// FileConfig defines the options for a FileWriter
+type FileConfig struct {
+	// Filename is the path of the active log file. Rotated segments are kept in
+	// the same directory, named with the time they were rotated.
+	Filename string
+
+	// MaxSize is the size in bytes after which the file is rotated. Zero
+	// disables size-based rotation.
+	MaxSize int64
+
+	// Interval is how often the file is rotated. Rotations are aligned to
+	// multiples of the interval, so an interval of 24 hours rotates at midnight
+	// UTC. Zero disables time-based rotation.
+	Interval time.Duration
+
+	// MaxBackups is the number of rotated segments to keep. Zero keeps all of
+	// them.
+	MaxBackups int
+
+	// MaxAge is how long rotated segments are kept. Zero keeps them regardless of
+	// age.
+	MaxAge time.Duration
+
+	// Compress gzips rotated segments.
+	Compress bool
+}
+
+// FileWriter is an io.Writer that writes log lines to a file, rotating it by
+// size and/or interval and pruning old segments. It reopens the file when the
+// process receives SIGHUP so that it can be used with logrotate.
+type FileWriter struct {
+	config FileConfig
+	now    func() time.Time
+
+	mu           sync.Mutex
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	closed       bool
+
+	mill    sync.Mutex
+	pending sync.WaitGroup
+
+	hup       chan os.Signal
+	closeOnce sync.Once
+}
+
+// rotatedTimeFormat is the layout of the timestamp in rotated segment names.
+// It avoids colons so that the names are valid on every platform.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// MARK: Public Functions
+
+// NewFileWriter opens the configured log file for appending, creating it and
+// its directory if needed, and returns a FileWriter for it
+func NewFileWriter(config FileConfig) (*FileWriter, error) {
+	if config.Filename == "" {
+		return nil, errors.New("log file name is required")
+	}
+
+	fw := &FileWriter{
+		config: config,
+		now:    time.Now,
+		hup:    make(chan os.Signal, 1),
+	}
+	if err := fw.open(); err != nil {
+		return nil, err
+	}
+
+	signal.Notify(fw.hup, syscall.SIGHUP)
+	go func() {
+		for range fw.hup {
+			_ = fw.Reopen()
+		}
+	}()
+
+	return fw, nil
+}
+
+// MARK: Public Methods
+
+// Write writes p to the log file, rotating it first if p would exceed the
+// maximum size or the rotation interval has elapsed. If the file can't be
+// rotated, p is written to the active file.
+func (fw *FileWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if err := fw.openIfNeeded(); err != nil {
+		return 0, err
+	}
+
+	sizeExceeded := fw.config.MaxSize > 0 && fw.size > 0 && fw.size+int64(len(p)) > fw.config.MaxSize
+	intervalElapsed := !fw.nextRotation.IsZero() && !fw.now().Before(fw.nextRotation)
+	if sizeExceeded || intervalElapsed {
+		if err := fw.rotate(); err != nil && fw.file == nil {
+			return 0, err
+		}
+	}
+
+	n, err := fw.file.Write(p)
+	fw.size += int64(n)
+	return n, err
+}
+
+// Flush commits the log file's contents to stable storage
+func (fw *FileWriter) Flush(ctx context.Context) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.file == nil {
+		return nil
+	}
+	return fw.file.Sync()
+}
+
+// Rotate rotates the log file immediately
+func (fw *FileWriter) Rotate() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if err := fw.openIfNeeded(); err != nil {
+		return err
+	}
+	return fw.rotate()
+}
+
+// Reopen closes and reopens the log file, such as after it has been moved by
+// an external tool like logrotate. If the file can't be reopened, the next
+// write tries again.
+func (fw *FileWriter) Reopen() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed {
+		return os.ErrClosed
+	}
+	if fw.file != nil {
+		err := fw.file.Close()
+		fw.file = nil
+		if err != nil {
+			return err
+		}
+	}
+	return fw.open()
+}
+
+// Close closes the log file and waits for rotated segments to be compressed and
+// pruned
+func (fw *FileWriter) Close() error {
+	var err error
+	fw.closeOnce.Do(func() {
+		signal.Stop(fw.hup)
+		close(fw.hup)
+
+		fw.mu.Lock()
+		if fw.file != nil {
+			err = fw.file.Close()
+		}
+		fw.file = nil
+		fw.closed = true
+		fw.mu.Unlock()
+
+		fw.pending.Wait()
+	})
+	return err
+}
+
+// MARK: Private Methods
+
+// open opens the log file for appending. The caller must hold fw.mu.
+func (fw *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(fw.config.Filename), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(fw.config.Filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	fw.file = f
+	fw.size = info.Size()
+	if fw.config.Interval > 0 {
+		fw.nextRotation = fw.now().Truncate(fw.config.Interval).Add(fw.config.Interval)
+	}
+	return nil
+}
+
+// openIfNeeded opens the log file if a failed rotation or reopen left it
+// closed. The caller must hold fw.mu.
+func (fw *FileWriter) openIfNeeded() error {
+	if fw.closed {
+		return os.ErrClosed
+	}
+	if fw.file == nil {
+		return fw.open()
+	}
+	return nil
+}
+
+// rotate renames the log file to a timestamped segment, opens a new log file
+// and compresses and prunes segments in the background. If the file can't be
+// renamed, it is reopened to keep writing to it. The caller must hold fw.mu.
+func (fw *FileWriter) rotate() error {
+	err := fw.file.Close()
+	fw.file = nil
+	if err != nil {
+		return err
+	}
+
+	// segment names have millisecond precision, so make sure rotations in
+	// quick succession don't overwrite each other
+	t := fw.now()
+	rotated := fw.segmentName(t)
+	for fileExists(rotated) || fileExists(rotated+".gz") {
+		t = t.Add(time.Millisecond)
+		rotated = fw.segmentName(t)
+	}
+	if err := os.Rename(fw.config.Filename, rotated); err != nil && !os.IsNotExist(err) {
+		_ = fw.open()
+		return err
+	}
+	if err := fw.open(); err != nil {
+		return err
+	}
+
+	fw.pending.Add(1)
+	go func() {
+		defer fw.pending.Done()
+		fw.mill.Lock()
+		defer fw.mill.Unlock()
+		if fw.config.Compress {
+			_ = compressFile(rotated)
+		}
+		fw.prune()
+	}()
+	return nil
+}
+
+// segmentName returns the name of a segment rotated at t
+func (fw *FileWriter) segmentName(t time.Time) string {
+	prefix, ext := fw.segmentAffixes()
+	return prefix + t.UTC().Format(rotatedTimeFormat) + ext
+}
+
+// segmentAffixes returns the prefix and extension of rotated segment names
+func (fw *FileWriter) segmentAffixes() (string, string) {
+	ext := filepath.Ext(fw.config.Filename)
+	return strings.TrimSuffix(fw.config.Filename, ext) + "-", ext
+}
+
+// prune removes the rotated segments that exceed the maximum number of backups
+// or are older than the maximum age
+func (fw *FileWriter) prune() {
+	if fw.config.MaxBackups <= 0 && fw.config.MaxAge <= 0 {
+		return
+	}
+
+	type segment struct {
+		path    string
+		rotated time.Time
+	}
+
+	dir := filepath.Dir(fw.config.Filename)
+	prefix, ext := fw.segmentAffixes()
+	prefix = filepath.Base(prefix)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var segments []segment
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(e.Name(), prefix)
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		t, err := time.Parse(rotatedTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, e.Name()), rotated: t})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].rotated.After(segments[j].rotated)
+	})
+
+	cutoff := fw.now().Add(-fw.config.MaxAge)
+	for i, s := range segments {
+		tooMany := fw.config.MaxBackups > 0 && i >= fw.config.MaxBackups
+		tooOld := fw.config.MaxAge > 0 && s.rotated.Before(cutoff)
+		if tooMany || tooOld {
+			_ = os.Remove(s.path)
+		}
+	}
+}
+
+// MARK: Private Functions
+
+// fileExists returns whether a file exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// compressFile gzips the file at path to path.gz and removes the original
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	_ = src.Close()
+	return os.Remove(path)
+}
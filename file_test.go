@@ -0,0 +1,280 @@
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// segments returns the sorted names of the rotated segments in dir
+func segments(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Name() != "app.log" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// testClock is a clock that can be moved forward while the writer reads it
+// from other goroutines
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Now returns the current time of the clock
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Add moves the clock forward by d
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestFileWriter(t *testing.T) {
+	t.Run("Size Rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		fw, err := NewFileWriter(FileConfig{
+			Filename: filepath.Join(dir, "app.log"),
+			MaxSize:  20,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := fw.Write([]byte("0123456789abcdef\n")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if s := segments(t, dir); len(s) != 2 {
+			t.Errorf("expected 2 rotated segments, got %v", s)
+		}
+		active, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+		if string(active) != "0123456789abcdef\n" {
+			t.Errorf("unexpected active file contents: %q", active)
+		}
+	})
+
+	t.Run("Interval Rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		clock := &testClock{now: time.Date(2021, 3, 1, 10, 30, 0, 0, time.UTC)}
+		fw, err := NewFileWriter(FileConfig{
+			Filename: filepath.Join(dir, "app.log"),
+			Interval: time.Hour,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.now = clock.Now
+		fw.nextRotation = clock.Now().Truncate(time.Hour).Add(time.Hour)
+
+		_, _ = fw.Write([]byte("first\n"))
+		clock.Add(20 * time.Minute)
+		_, _ = fw.Write([]byte("second\n"))
+		clock.Add(20 * time.Minute)
+		_, _ = fw.Write([]byte("third\n"))
+		_ = fw.Close()
+
+		s := segments(t, dir)
+		if len(s) != 1 || s[0] != "app-2021-03-01T11-10-00.000.log" {
+			t.Fatalf("expected one segment rotated at 11:10, got %v", s)
+		}
+		rotated, _ := os.ReadFile(filepath.Join(dir, s[0]))
+		if string(rotated) != "first\nsecond\n" {
+			t.Errorf("unexpected rotated contents: %q", rotated)
+		}
+	})
+
+	t.Run("Compression And Retention", func(t *testing.T) {
+		dir := t.TempDir()
+		clock := &testClock{now: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)}
+		fw, err := NewFileWriter(FileConfig{
+			Filename:   filepath.Join(dir, "app.log"),
+			MaxBackups: 2,
+			MaxAge:     time.Hour,
+			Compress:   true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.now = clock.Now
+
+		// a stale segment from a previous run, older than the maximum age
+		stale := filepath.Join(dir, "app-2021-02-28T00-00-00.000.log.gz")
+		_ = os.WriteFile(stale, nil, 0644)
+
+		for i := 0; i < 4; i++ {
+			_, _ = fw.Write([]byte("line\n"))
+			clock.Add(time.Minute)
+			if err := fw.Rotate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_ = fw.Close()
+
+		s := segments(t, dir)
+		want := []string{"app-2021-03-01T00-03-00.000.log.gz", "app-2021-03-01T00-04-00.000.log.gz"}
+		if strings.Join(s, ",") != strings.Join(want, ",") {
+			t.Fatalf("expected segments %v, got %v", want, s)
+		}
+
+		f, _ := os.Open(filepath.Join(dir, s[1]))
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, _ := io.ReadAll(gz)
+		if string(contents) != "line\n" {
+			t.Errorf("unexpected decompressed contents: %q", contents)
+		}
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.log")
+		fw, err := NewFileWriter(FileConfig{Filename: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fw.Close()
+
+		_, _ = fw.Write([]byte("before\n"))
+		// simulate logrotate moving the file out of the way
+		if err := os.Rename(name, name+".1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := fw.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fw.Write([]byte("after\n"))
+
+		moved, _ := os.ReadFile(name + ".1")
+		active, _ := os.ReadFile(name)
+		if string(moved) != "before\n" || string(active) != "after\n" {
+			t.Errorf("unexpected contents after reopen: moved %q, active %q", moved, active)
+		}
+	})
+
+	t.Run("Rotation Failure", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("directory permissions don't apply to root")
+		}
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.log")
+		fw, err := NewFileWriter(FileConfig{Filename: name, MaxSize: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fw.Close()
+
+		_, _ = fw.Write([]byte("first\n"))
+		if err := os.Chmod(dir, 0555); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chmod(dir, 0755)
+
+		if err := fw.Rotate(); err == nil {
+			t.Error("expected an error rotating in a read-only directory")
+		}
+		if _, err := fw.Write([]byte("second\n")); err != nil {
+			t.Fatalf("expected writes to the active file after a failed rotation, got %v", err)
+		}
+		if err := os.Chmod(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fw.Write([]byte("third\n"))
+
+		active, _ := os.ReadFile(name)
+		if s := segments(t, dir); len(s) != 1 || string(active) != "third\n" {
+			t.Errorf("unexpected segments %v and active file contents %q", s, active)
+		}
+	})
+
+	t.Run("Reopen Failure", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.log")
+		fw, err := NewFileWriter(FileConfig{Filename: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fw.Close()
+
+		_, _ = fw.Write([]byte("before\n"))
+		// a directory in the file's place can't be opened
+		if err := os.Rename(name, name+".1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Mkdir(name, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fw.Reopen(); err == nil {
+			t.Error("expected an error reopening a directory")
+		}
+		if _, err := fw.Write([]byte("lost\n")); err == nil {
+			t.Error("expected an error writing while the file can't be opened")
+		}
+
+		if err := os.Remove(name); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte("after\n")); err != nil {
+			t.Fatalf("expected the file to be reopened by the next write, got %v", err)
+		}
+		active, _ := os.ReadFile(name)
+		if string(active) != "after\n" {
+			t.Errorf("unexpected contents after reopen: %q", active)
+		}
+	})
+
+	t.Run("As Logger Output", func(t *testing.T) {
+		dir := t.TempDir()
+		fw, err := NewFileWriter(FileConfig{Filename: filepath.Join(dir, "app.log")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Output: fw,
+			Async:  &AsyncConfig{},
+		})
+
+		l.Infoln("This is an info statement.")
+		if err := l.Close(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte("late\n")); err == nil {
+			t.Error("expected the file to be closed with the logger")
+		}
+
+		active, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+		if !strings.Contains(string(active), `"message":"This is an info statement."`) {
+			t.Errorf("unexpected file contents: %q", active)
+		}
+	})
+}
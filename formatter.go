@@ -0,0 +1,107 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MARK: Types
+
+// Entry is a log message as it is passed to a Formatter.
+type Entry struct {
+	// Time is when the message was logged.
+	Time time.Time
+
+	// Timestamp is Time formatted with the logger's TimeFormat.
+	Timestamp string
+
+	// Level is the level the message was logged at.
+	Level Level
+
+	// Tags are the logger's tags.
+	Tags []string
+
+	// Message is the message with leading and trailing whitespace trimmed.
+	Message string
+
+	// Metadata is the data logged with the message, or nil. Errors that don't
+	// implement json.Marshaler have been converted to their message.
+	Metadata interface{}
+
+	// File is the "file:line" the message was logged from, or empty if the
+	// logger doesn't log the caller.
+	File string
+
+	// Fields are the fields of the logger and the log call, with later fields
+	// replacing earlier fields with the same key.
+	Fields []Field
+}
+
+// Formatter formats entries for a custom Format.
+type Formatter interface {
+	// Format returns the entry as a single log line, without a trailing
+	// newline.
+	Format(e Entry) string
+}
+
+// FormatterFunc is an adapter to allow the use of ordinary functions as
+// formatters.
+type FormatterFunc func(e Entry) string
+
+// Format calls f(e).
+func (f FormatterFunc) Format(e Entry) string {
+	return f(e)
+}
+
+// MARK: Registry
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[Format]Formatter{}
+)
+
+// RegisterFormat makes a custom format available by the provided name, so it
+// can be selected with SetupLogger, LoggerConfig.Format or Sink.Format.
+// Output of a custom format is colorized like the built-in formats when
+// colorizing is enabled. If RegisterFormat is called twice with the same
+// name, with a built-in format's name, or if formatter is nil, it panics.
+func RegisterFormat(name Format, formatter Formatter) {
+	if formatter == nil {
+		panic("log: RegisterFormat formatter is nil")
+	}
+	if name.builtin() {
+		panic(fmt.Sprintf("log: RegisterFormat called for built-in format %q", name))
+	}
+
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	if _, dup := formatters[name]; dup {
+		panic(fmt.Sprintf("log: RegisterFormat called twice for format %q", name))
+	}
+	formatters[name] = formatter
+}
+
+// registeredFormatter returns the custom formatter registered for a format
+func registeredFormatter(name Format) (Formatter, bool) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	f, ok := formatters[name]
+	return f, ok
+}
+
+// MARK: Methods
+
+// entry returns the message as an Entry
+func (m logMessage) entry() Entry {
+	return Entry{
+		Time:      m.time,
+		Timestamp: m.Timestamp,
+		Level:     m.rawLevel,
+		Tags:      m.Tags,
+		Message:   m.Message,
+		Metadata:  m.Metadata,
+		File:      m.File,
+		Fields:    m.allFields(),
+	}
+}
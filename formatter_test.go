@@ -0,0 +1,79 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("test-house", FormatterFunc(func(e Entry) string {
+		return fmt.Sprintf("%s|%s|%s|%v|%v", e.Level, strings.Join(e.Tags, ","), e.Message, e.Metadata, e.File != "")
+	}))
+
+	t.Run("Selectable", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:     LogLevelDebug,
+			Format:    "test-house",
+			LogCaller: true,
+			Tags:      []string{"a", "b"},
+			Output:    &buf,
+		})
+		l.Infod("  hello ", 5)
+
+		if got, want := buf.String(), "INFO|a,b|hello|5|true\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Sink", func(t *testing.T) {
+		var house, pretty syncBuffer
+		l := NewLogger(LoggerConfig{
+			Sinks: []Sink{
+				{Output: &house, Level: LogLevelInfo, Format: "test-house"},
+				{Output: &pretty, Level: LogLevelInfo, Format: LogFormatPretty},
+			},
+		})
+		l.Warnln("careful")
+
+		if got, want := house.String(), "WARN||careful|<nil>|false\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if !strings.Contains(pretty.String(), "[WARN] careful") {
+			t.Errorf("unexpected pretty output %q", pretty.String())
+		}
+	})
+
+	t.Run("Unregistered", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelDebug, Format: "test-missing", Output: &buf})
+		l.Infoln("fallback")
+
+		if !strings.Contains(buf.String(), "[INFO] fallback") {
+			t.Errorf("expected pretty output, got %q", buf.String())
+		}
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			format    Format
+			formatter Formatter
+		}{
+			{"Duplicate", "test-house", FormatterFunc(func(Entry) string { return "" })},
+			{"Built-in", LogFormatJSON, FormatterFunc(func(Entry) string { return "" })},
+			{"Nil", "test-nil", nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				defer func() {
+					if recover() == nil {
+						t.Error("expected panic")
+					}
+				}()
+				RegisterFormat(tt.format, tt.formatter)
+			})
+		}
+	})
+}
@@ -1,5 +1,5 @@
 module github.com/parkhub/go-parkhub-logger
 
-go 1.15
+go 1.21
 
 require github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31
@@ -0,0 +1,147 @@
+package log
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MARK: Methods
+
+// appendGoogleCloud appends the message as JSON with the special fields
+// recognized by Google Cloud Logging when they are written by the logging
+// agent
+func (m logMessage) appendGoogleCloud(dst []byte) []byte {
+	dst = append(dst, '{')
+	dst = appendJSONKey(dst, "time")
+	dst = append(dst, '"')
+	dst = m.time.UTC().AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, '"')
+	dst = appendJSONKey(dst, "severity")
+	dst = appendJSONString(dst, m.rawLevel.googleCloudSeverity())
+	dst = appendJSONKey(dst, "message")
+	dst = appendJSONString(dst, m.Message)
+
+	if m.logCaller {
+		file, line := m.File, ""
+		if i := strings.LastIndex(m.File, ":"); i >= 0 {
+			file, line = m.File[:i], m.File[i+1:]
+		}
+		dst = appendJSONKey(dst, "logging.googleapis.com/sourceLocation")
+		dst = append(dst, '{')
+		dst = appendJSONKey(dst, "file")
+		dst = appendJSONString(dst, file)
+		if line != "" {
+			dst = appendJSONKey(dst, "line")
+			dst = appendJSONString(dst, line)
+		}
+		dst = append(dst, '}')
+	}
+
+	if len(m.Tags) > 0 {
+		dst = appendJSONKey(dst, "logging.googleapis.com/labels")
+		dst = appendGoogleCloudLabels(dst, m.Tags)
+	}
+
+	if rl, ok := m.Metadata.(requestLog); ok {
+		dst = appendJSONKey(dst, "httpRequest")
+		dst = append(dst, '{')
+		dst = appendJSONKey(dst, "requestMethod")
+		dst = appendJSONString(dst, rl.method)
+		dst = appendJSONKey(dst, "requestUrl")
+		dst = appendJSONString(dst, rl.url)
+		if rl.status != 0 {
+			dst = appendJSONKey(dst, "status")
+			dst = strconv.AppendInt(dst, int64(rl.status), 10)
+		}
+		if rl.userAgent != "" {
+			dst = appendJSONKey(dst, "userAgent")
+			dst = appendJSONString(dst, rl.userAgent)
+		}
+		if rl.remoteIP != "" {
+			dst = appendJSONKey(dst, "remoteIp")
+			dst = appendJSONString(dst, rl.remoteIP)
+		}
+		if rl.protocol != "" {
+			dst = appendJSONKey(dst, "protocol")
+			dst = appendJSONString(dst, rl.protocol)
+		}
+		dst = appendJSONKey(dst, "latency")
+		dst = append(dst, '"')
+		dst = strconv.AppendFloat(dst, rl.latency.Seconds(), 'f', -1, 64)
+		dst = append(dst, "s\""...)
+		dst = append(dst, '}')
+	}
+
+	if m.Metadata != nil {
+		dst = appendJSONKey(dst, "metadata")
+		dst = appendJSONValue(dst, m.Metadata)
+	}
+
+	dst = appendJSONFields(dst, m.allFields(), isGoogleCloudKey)
+	return append(dst, '}')
+}
+
+// MARK: Private Functions
+
+// isGoogleCloudKey reports whether a key is one of the keys of a
+// googleCloudEntry
+func isGoogleCloudKey(key string) bool {
+	switch key {
+	case "time", "severity", "message", "logging.googleapis.com/sourceLocation",
+		"logging.googleapis.com/labels", "httpRequest", "metadata":
+		return true
+	}
+	return false
+}
+
+// appendGoogleCloudLabels appends tags as a JSON object of labels. Tags in the
+// form "key:value" or "key=value" become a label with that key and value, and
+// the rest are joined with commas under the "tags" label. When tags repeat a
+// key, the last one is used.
+func appendGoogleCloudLabels(dst []byte, tags []string) []byte {
+	plain := false
+	for _, tag := range tags {
+		if strings.IndexAny(tag, ":=") <= 0 {
+			plain = true
+		}
+	}
+
+	dst = append(dst, '{')
+	for i, tag := range tags {
+		k := strings.IndexAny(tag, ":=")
+		if k <= 0 || plain && tag[:k] == "tags" || labelRepeated(tags[i+1:], tag[:k]) {
+			continue
+		}
+		dst = appendJSONKey(dst, tag[:k])
+		dst = appendJSONString(dst, tag[k+1:])
+	}
+
+	if plain {
+		dst = appendJSONKey(dst, "tags")
+		dst = append(dst, '"')
+		first := true
+		for _, tag := range tags {
+			if strings.IndexAny(tag, ":=") > 0 {
+				continue
+			}
+			if !first {
+				dst = append(dst, ',')
+			}
+			first = false
+			dst = appendJSONEscaped(dst, tag)
+		}
+		dst = append(dst, '"')
+	}
+	return append(dst, '}')
+}
+
+// labelRepeated reports whether any of the tags is a label with the key
+func labelRepeated(tags []string, key string) bool {
+	for _, tag := range tags {
+		if k := strings.IndexAny(tag, ":="); k > 0 && tag[:k] == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoogleCloud(t *testing.T) {
+	t.Run("Special Fields", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:     LogLevelDebug,
+			Format:    LogFormatGoogleCloud,
+			LogCaller: true,
+			Tags:      []string{"some-api", "env:production"},
+			Output:    &buf,
+		})
+		l.Warnd("This is a warning.", map[string]int{"spaces": 120})
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry["severity"] != "WARNING" || entry["message"] != "This is a warning." {
+			t.Errorf("unexpected severity or message: %v", entry)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, entry["time"].(string)); err != nil {
+			t.Errorf("invalid time: %v", err)
+		}
+		loc, _ := entry["logging.googleapis.com/sourceLocation"].(map[string]interface{})
+		if loc["file"] != "googlecloud_test.go" || loc["line"] == "" {
+			t.Errorf("unexpected source location: %v", loc)
+		}
+		labels, _ := entry["logging.googleapis.com/labels"].(map[string]interface{})
+		if labels["tags"] != "some-api" || labels["env"] != "production" {
+			t.Errorf("unexpected labels: %v", labels)
+		}
+		if md, _ := entry["metadata"].(map[string]interface{}); md["spaces"] != 120.0 {
+			t.Errorf("unexpected metadata: %v", entry["metadata"])
+		}
+		if _, ok := entry["httpRequest"]; ok {
+			t.Error("unexpected httpRequest")
+		}
+	})
+
+	t.Run("Repeated Labels", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatGoogleCloud,
+			Tags:   []string{"env:staging", "a", "tags:x", "env=production", "b"},
+			Output: &buf,
+		})
+		l.Infoln("This is an info statement.")
+
+		var entry struct {
+			Labels map[string]string `json:"logging.googleapis.com/labels"`
+		}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if len(entry.Labels) != 2 || entry.Labels["env"] != "production" || entry.Labels["tags"] != "a,b" ||
+			strings.Count(buf.String(), `"env"`) != 1 {
+			t.Errorf("unexpected labels: %v", entry.Labels)
+		}
+	})
+
+	t.Run("Request Log", func(t *testing.T) {
+		var buf syncBuffer
+		rl := NewRequestLogger(RequestLoggerConfig{
+			Logger: NewLogger(LoggerConfig{
+				Level:  LogLevelDebug,
+				Format: LogFormatGoogleCloud,
+				Output: &buf,
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/lots?id=1", nil)
+		req.Header.Set("User-Agent", "test-agent")
+		rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})).ServeHTTP(httptest.NewRecorder(), req)
+
+		deadline := time.Now().Add(time.Second)
+		for buf.String() == "" {
+			if time.Now().After(deadline) {
+				t.Fatal("request was not logged")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		var entry struct {
+			Severity    string
+			HTTPRequest map[string]interface{} `json:"httpRequest"`
+		}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry.Severity != "DEBUG" {
+			t.Errorf("expected DEBUG severity, got %s", entry.Severity)
+		}
+		r := entry.HTTPRequest
+		if r["requestMethod"] != "POST" || r["requestUrl"] != "/lots?id=1" || r["status"] != 201.0 ||
+			r["userAgent"] != "test-agent" || r["remoteIp"] != "192.0.2.1" || r["protocol"] != "HTTP/1.1" {
+			t.Errorf("unexpected httpRequest: %v", r)
+		}
+		if latency, _ := r["latency"].(string); !strings.HasSuffix(latency, "s") {
+			t.Errorf("unexpected latency: %v", r["latency"])
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	l := NewLogger(LoggerConfig{Level: LogLevelInfo, Output: &syncBuffer{}})
+	sl := l.Sublogger("sub").WithField("a", 1)
+
+	for _, tt := range []struct {
+		level Level
+		want  bool
+	}{
+		{LogLevelTrace, false},
+		{LogLevelDebug, false},
+		{LogLevelInfo, true},
+		{LogLevelError, true},
+	} {
+		if got := l.Enabled(tt.level); got != tt.want {
+			t.Errorf("logger Enabled(%s) = %v, want %v", tt.level, got, tt.want)
+		}
+		if got := sl.Enabled(tt.level); got != tt.want {
+			t.Errorf("sublogger Enabled(%s) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	t.Run("Sinks", func(t *testing.T) {
+		l := NewLogger(LoggerConfig{Sinks: []Sink{
+			{Output: &syncBuffer{}, Level: LogLevelError},
+			{Output: &syncBuffer{}, Level: LogLevelDebug},
+		}})
+		if !l.Enabled(LogLevelDebug) || l.Enabled(LogLevelTrace) {
+			t.Error("expected the lowest sink level to be enabled")
+		}
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		SetupLoggerWithConfig(LoggerConfig{Level: LogLevelWarn, Output: &syncBuffer{}})
+		defer SetupLocalLogger(LogLevelDebug)
+		if Enabled(LogLevelInfo) || !Enabled(LogLevelWarn) {
+			t.Error("unexpected default logger levels")
+		}
+	})
+}
+
+func TestLazy(t *testing.T) {
+	var buf syncBuffer
+	l := NewLogger(LoggerConfig{Level: LogLevelInfo, LogCaller: true, Output: &buf})
+
+	calls := 0
+	fn := func() (string, interface{}) {
+		calls++
+		return "expensive", map[string]int{"spaces": 120}
+	}
+
+	sl := l.Sublogger("sub")
+	sl.Tracefn(fn)
+	sl.Debugfn(fn)
+	sl.Logfn(LogLevelDebug, fn)
+	if calls != 0 {
+		t.Fatalf("fn called %d times for disabled levels", calls)
+	}
+
+	sl.Infofn(fn)
+	sl.Warnfn(fn)
+	sl.Errorfn(fn)
+	l.Logfn(LogLevelInfo, fn)
+	if calls != 4 {
+		t.Fatalf("fn called %d times, want 4", calls)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+	for _, line := range lines[:3] {
+		if !strings.Contains(line, "[lazy_test.go:") || !strings.Contains(line, "(sub) expensive map[string]int{\"spaces\":120}") {
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+
+	t.Run("Package", func(t *testing.T) {
+		var buf syncBuffer
+		SetupLoggerWithConfig(LoggerConfig{Level: LogLevelInfo, LogCaller: true, Output: &buf})
+		defer SetupLocalLogger(LogLevelDebug)
+
+		calls := 0
+		fn := func() (string, interface{}) {
+			calls++
+			return "expensive", nil
+		}
+		Debugfn(fn)
+		Tracefn(fn)
+		Infofn(fn)
+		Warnfn(fn)
+		Errorfn(fn)
+		Logfn(LogLevelInfo, fn)
+
+		if calls != 4 {
+			t.Errorf("fn called %d times, want 4", calls)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("unexpected lines: %q", lines)
+		}
+		for _, line := range lines[:3] {
+			if !strings.Contains(line, "[lazy_test.go:") {
+				t.Errorf("unexpected caller in %q", line)
+			}
+		}
+	})
+}
@@ -1,11 +1,13 @@
 // Package log provides a singular interface to create logs as well as filtering
-// them out based on level. It also provides two types of formatting; json or
-// pretty. The logger doesn't ship any logs.
+// them out based on level. It formats logs as json, pretty, logfmt, Google
+// Cloud Logging JSON, Elastic Common Schema JSON or a registered custom
+// format. Logs are written to stdout by default, and can be shipped to rotating
+// files, syslog or Loggly.
 package log
 
 import (
+	"context"
 	"fmt"
-	"os"
 )
 
 // LoggerSingleton is the main logging instance.
@@ -25,28 +27,37 @@ func SetupCloudLogger(level Level, tags []string) {
 	SetupLogger(level, LogFormatJSON, TimeFormatLoggly, false, true, tags)
 }
 
-// SetupLogger creates a new logger.
+// SetupLogger creates a new logger that writes to os.Stdout.
 func SetupLogger(level Level, format Format, timeFormat TimeFormat, colorizeOutput bool, logCaller bool, tags []string) {
+	SetupLoggerWithConfig(LoggerConfig{
+		Level:      level,
+		Format:     format,
+		TimeFormat: timeFormat,
+		Colorize:   colorizeOutput,
+		LogCaller:  logCaller,
+		Tags:       tags,
+	})
+}
+
+// SetupLoggerWithConfig creates a new logger with the provided options.
+func SetupLoggerWithConfig(config LoggerConfig) {
 	if LoggerSingleton != nil {
 		// If the logger has already been created, then update its properties
-		LoggerSingleton.rawLevel = level
-		LoggerSingleton.format = format
-		LoggerSingleton.timeFormat = timeFormat
-		LoggerSingleton.colorizeOutput = colorizeOutput
-		LoggerSingleton.tags = tags
+		LoggerSingleton.rawLevel = config.Level
+		LoggerSingleton.format = config.Format
+		LoggerSingleton.timeFormat = config.TimeFormat
+		LoggerSingleton.location = config.Location
+		LoggerSingleton.colorizeOutput = config.Colorize
+		LoggerSingleton.logCaller = config.LogCaller
+		LoggerSingleton.tags = config.Tags
+		LoggerSingleton.flushTimeout = config.FlushTimeout
+		LoggerSingleton.setOutputs(config)
+		LoggerSingleton.setOptions(config)
 		return
 	}
 
 	// Setup logger with options.
-	LoggerSingleton = &logger{
-		rawLevel:       level,
-		format:         format,
-		timeFormat:     timeFormat,
-		colorizeOutput: colorizeOutput,
-		logCaller:      logCaller,
-		tags:           tags,
-		exitFunc:       func() { os.Exit(1) },
-	}
+	LoggerSingleton = newLogger(config)
 }
 
 // MARK: Standard output
@@ -68,124 +79,226 @@ func Stdd(output string, d interface{}) {
 
 // MARK: Generic log
 
+// Enabled reports whether messages at the level are logged by the default
+// logger
+func Enabled(level Level) bool {
+	return LoggerSingleton.Enabled(level)
+}
+
 // Logln prints the output followed by a newline
 func Logln(level Level, output string) {
-	LoggerSingleton.Logln(level, output)
+	LoggerSingleton.logln(level, output)
 }
 
 // Logf prints the formatted output
 func Logf(level Level, format string, a ...interface{}) {
-	LoggerSingleton.Logf(level, format, a...)
+	LoggerSingleton.logf(level, format, a...)
 }
 
 // Logd prints output string and data
 func Logd(level Level, output string, d interface{}) {
-	LoggerSingleton.Logd(level, output, d)
+	LoggerSingleton.logd(level, output, d)
+}
+
+// Logfn prints the output string and data returned by fn, which is only called
+// if the level is enabled
+func Logfn(level Level, fn func() (string, interface{})) {
+	LoggerSingleton.logfn(level, fn)
+}
+
+// Logw prints output string and fields from alternating keys and values
+func Logw(level Level, output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(level, output, keysAndValues...)
 }
 
 // MARK: Trace
 
 // Traceln prints the output followed by a newline
 func Traceln(output string) {
-	Logln(LogLevelTrace, output)
+	LoggerSingleton.logln(LogLevelTrace, output)
 }
 
 // Tracef prints the formatted output
 func Tracef(format string, a ...interface{}) {
-	Logf(LogLevelTrace, format, a...)
+	LoggerSingleton.logf(LogLevelTrace, format, a...)
 }
 
 // Traced prints the output string and data
 func Traced(output string, d interface{}) {
-	Logd(LogLevelTrace, output, d)
+	LoggerSingleton.logd(LogLevelTrace, output, d)
+}
+
+// Tracew prints the output string and fields from alternating keys and values
+func Tracew(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelTrace, output, keysAndValues...)
+}
+
+// Tracefn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func Tracefn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelTrace, fn)
 }
 
 // MARK: Debug
 
 // Debugln prints the output followed by a newline.
 func Debugln(output string) {
-	Logln(LogLevelDebug, output)
+	LoggerSingleton.logln(LogLevelDebug, output)
 }
 
 // Debugf prints the formatted output.
 func Debugf(format string, a ...interface{}) {
-	Logf(LogLevelDebug, format, a...)
+	LoggerSingleton.logf(LogLevelDebug, format, a...)
 }
 
 // Debugd prints output string and data.
 func Debugd(output string, d interface{}) {
-	Logd(LogLevelDebug, output, d)
+	LoggerSingleton.logd(LogLevelDebug, output, d)
+}
+
+// Debugw prints the output string and fields from alternating keys and values
+func Debugw(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelDebug, output, keysAndValues...)
+}
+
+// Debugfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func Debugfn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelDebug, fn)
 }
 
 // MARK: Info
 
 // Infoln prints the output followed by a newline.
 func Infoln(output string) {
-	Logln(LogLevelInfo, output)
+	LoggerSingleton.logln(LogLevelInfo, output)
 }
 
 // Infof prints the formatted output.
 func Infof(format string, a ...interface{}) {
-	Logf(LogLevelInfo, format, a...)
+	LoggerSingleton.logf(LogLevelInfo, format, a...)
 }
 
 // Infod prints output string and data.
 func Infod(output string, d interface{}) {
-	Logd(LogLevelInfo, output, d)
+	LoggerSingleton.logd(LogLevelInfo, output, d)
+}
+
+// Infow prints the output string and fields from alternating keys and values
+func Infow(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelInfo, output, keysAndValues...)
+}
+
+// Infofn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func Infofn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelInfo, fn)
 }
 
 // MARK: Warn
 
 // Warnln prints the output followed by a newline.
 func Warnln(output string) {
-	Logln(LogLevelWarn, output)
+	LoggerSingleton.logln(LogLevelWarn, output)
 }
 
 // Warnf prints the formatted output.
 func Warnf(format string, a ...interface{}) {
-	Logf(LogLevelWarn, format, a...)
+	LoggerSingleton.logf(LogLevelWarn, format, a...)
 }
 
 // Warnd prints output string and data.
 func Warnd(output string, d interface{}) {
-	Logd(LogLevelWarn, output, d)
+	LoggerSingleton.logd(LogLevelWarn, output, d)
+}
+
+// Warnw prints the output string and fields from alternating keys and values
+func Warnw(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelWarn, output, keysAndValues...)
+}
+
+// Warnfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func Warnfn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelWarn, fn)
 }
 
 // MARK: Error
 
 // Errorln prints the output followed by a newline.
 func Errorln(output string) {
-	Logln(LogLevelError, output)
+	LoggerSingleton.logln(LogLevelError, output)
 }
 
 // Errorf prints the formatted output.
 func Errorf(format string, a ...interface{}) {
-	Logf(LogLevelError, format, a...)
+	LoggerSingleton.logf(LogLevelError, format, a...)
 }
 
 // Errord prints output string and data.
 func Errord(output string, d interface{}) {
-	Logd(LogLevelError, output, d)
+	LoggerSingleton.logd(LogLevelError, output, d)
+}
+
+// Errorw prints the output string and fields from alternating keys and values
+func Errorw(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelError, output, keysAndValues...)
+}
+
+// Errorfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func Errorfn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelError, fn)
 }
 
 // MARK: Fatal
 
 // Fatalln prints the output followed by a newline and calls os.Exit(1).
 func Fatalln(output string) {
-	Logln(LogLevelFatal, output)
+	LoggerSingleton.logln(LogLevelFatal, output)
+	LoggerSingleton.exit()
 }
 
-// Fatalf prints the formatted output.
+// Fatalf prints the formatted output and calls os.Exit(1).
 func Fatalf(format string, a ...interface{}) {
-	Logf(LogLevelFatal, format, a...)
+	LoggerSingleton.logf(LogLevelFatal, format, a...)
+	LoggerSingleton.exit()
 }
 
-// Fatald prints output string and data.
+// Fatald prints output string and data and calls os.Exit(1).
 func Fatald(output string, d interface{}) {
-	Logd(LogLevelFatal, output, d)
+	LoggerSingleton.logd(LogLevelFatal, output, d)
 	LoggerSingleton.exit()
 }
 
+// Fatalw prints the output string and fields from alternating keys and
+// values and calls os.Exit(1).
+func Fatalw(output string, keysAndValues ...interface{}) {
+	LoggerSingleton.logw(LogLevelFatal, output, keysAndValues...)
+	LoggerSingleton.exit()
+}
+
+// Fatalfn prints the output string and data returned by fn, which is only
+// called if the level is enabled, and calls os.Exit(1).
+func Fatalfn(fn func() (string, interface{})) {
+	LoggerSingleton.logfn(LogLevelFatal, fn)
+	LoggerSingleton.exit()
+}
+
+// MARK: Lifecycle
+
+// Flush waits for every message logged before the call to be written by the
+// default logger, until the context is done.
+func Flush(ctx context.Context) error {
+	return LoggerSingleton.Flush(ctx)
+}
+
+// Shutdown flushes the default logger and closes its outputs. It should be
+// called before the application exits so that buffered messages aren't lost.
+func Shutdown(ctx context.Context) error {
+	return LoggerSingleton.Close(ctx)
+}
+
 // MARK: Private Functions
 
 func joinToString(a ...interface{}) string {
@@ -0,0 +1,187 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MARK: Methods
+
+// appendLogfmt appends the message as logfmt key=value pairs. Metadata that
+// marshals to a JSON object or array is flattened into dotted keys.
+func (m logMessage) appendLogfmt(dst []byte) []byte {
+	dst = append(dst, "timestamp="...)
+	dst = appendLogfmtString(dst, m.Timestamp)
+	dst = appendLogfmtPair(dst, "level", m.Level)
+	if len(m.Tags) > 0 {
+		dst = appendLogfmtTags(dst, m.Tags)
+	}
+	if m.logCaller {
+		dst = appendLogfmtPair(dst, "file", m.File)
+	}
+	dst = appendLogfmtPair(dst, "message", m.Message)
+	for _, f := range m.allFields() {
+		dst = appendLogfmtValue(dst, f.Key, fieldValue(f.Value))
+	}
+	if m.Metadata != nil {
+		dst = appendLogfmtValue(dst, "metadata", m.Metadata)
+	}
+	return dst
+}
+
+// MARK: Private Functions
+
+// logfmtMetadata converts metadata into the generic values it marshals to as
+// JSON, so that structs and maps can be flattened. Values that can't be
+// marshalled are formatted with fmt instead.
+func logfmtMetadata(d interface{}) interface{} {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Sprintf("%+v", d)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return fmt.Sprintf("%+v", d)
+	}
+	return generic
+}
+
+// appendLogfmtValue appends v under key, flattening objects and arrays into
+// dotted keys. Strings, numbers and booleans are appended directly, and other
+// values are converted to the generic values they marshal to first.
+func appendLogfmtValue(dst []byte, key string, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return appendLogfmtPair(dst, key, "null")
+	case string:
+		return appendLogfmtPair(dst, key, v)
+	case fmt.Stringer:
+		// structs and maps are flattened when they marshal to an object, so
+		// that a String method meant for people doesn't hide their fields
+		if logfmtIsObject(v) {
+			if generic, ok := logfmtMetadata(v).(map[string]interface{}); ok && len(generic) > 0 {
+				return appendLogfmtValue(dst, key, generic)
+			}
+		}
+		return appendLogfmtPair(dst, key, v.String())
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		dst = appendLogfmtKey(append(dst, ' '), key)
+		return appendJSONValue(append(dst, '='), v)
+	case float32:
+		return appendLogfmtFloat(dst, key, float64(v), 32)
+	case float64:
+		return appendLogfmtFloat(dst, key, v, 64)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			dst = appendLogfmtValue(dst, key+"."+k, v[k])
+		}
+		return dst
+	case []interface{}:
+		for i, e := range v {
+			dst = appendLogfmtValue(dst, key+"."+strconv.Itoa(i), e)
+		}
+		return dst
+	}
+	return appendLogfmtValue(dst, key, logfmtMetadata(v))
+}
+
+// logfmtIsObject reports whether v is a struct or a map, or a pointer to one
+func logfmtIsObject(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Struct || rv.Kind() == reflect.Map
+}
+
+// appendLogfmtFloat appends f under key as it is written in JSON, or
+// formatted with fmt if JSON can't represent it
+func appendLogfmtFloat(dst []byte, key string, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendLogfmtPair(dst, key, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	dst = appendLogfmtKey(append(dst, ' '), key)
+	return appendJSONFloat(append(dst, '='), f, bits)
+}
+
+// appendLogfmtTags appends the tags joined with commas under the "tags" key
+func appendLogfmtTags(dst []byte, tags []string) []byte {
+	for _, tag := range tags {
+		if logfmtNeedsQuote(tag) {
+			return appendLogfmtPair(dst, "tags", strings.Join(tags, ","))
+		}
+	}
+
+	dst = append(dst, " tags="...)
+	for i, tag := range tags {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, tag...)
+	}
+	return dst
+}
+
+// appendLogfmtPair appends a key=value pair, preceded by a space
+func appendLogfmtPair(dst []byte, key, value string) []byte {
+	dst = appendLogfmtKey(append(dst, ' '), key)
+	dst = append(dst, '=')
+	return appendLogfmtString(dst, value)
+}
+
+// appendLogfmtKey appends the key with the characters that aren't allowed in a
+// key replaced with underscores
+func appendLogfmtKey(dst []byte, key string) []byte {
+	if key == "" {
+		return append(dst, '_')
+	}
+	for i, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			dst = append(dst, '_')
+			continue
+		}
+		if r < utf8.RuneSelf {
+			dst = append(dst, byte(r))
+			continue
+		}
+		dst = append(dst, key[i:i+utf8.RuneLen(r)]...)
+	}
+	return dst
+}
+
+// appendLogfmtString appends the value, quoted and escaped if it is empty or
+// contains spaces, quotes, equals signs or control characters
+func appendLogfmtString(dst []byte, value string) []byte {
+	if logfmtNeedsQuote(value) {
+		return strconv.AppendQuote(dst, value)
+	}
+	return append(dst, value...)
+}
+
+// logfmtNeedsQuote reports whether a value must be quoted
+func logfmtNeedsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || unicode.IsControl(r) || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
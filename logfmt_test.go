@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogfmt(t *testing.T) {
+	type location struct {
+		City string `json:"city"`
+	}
+	type lot struct {
+		Name     string   `json:"name"`
+		Spaces   int      `json:"spaces"`
+		Location location `json:"location"`
+		Gates    []string `json:"gates"`
+	}
+
+	tests := []struct {
+		name     string
+		message  string
+		metadata interface{}
+		want     string
+	}{
+		{
+			name:    "Message",
+			message: "This is an info statement.",
+			want:    `message="This is an info statement."`,
+		},
+		{
+			name:    "Escaping",
+			message: "say \"hi\"\nnow a=b",
+			want:    `message="say \"hi\"\nnow a=b"`,
+		},
+		{
+			name:    "Empty Message",
+			message: "",
+			want:    `message=""`,
+		},
+		{
+			name:     "Scalar Metadata",
+			message:  "count",
+			metadata: 10000,
+			want:     `message=count metadata=10000`,
+		},
+		{
+			name:     "Error Metadata",
+			message:  "failed",
+			metadata: errors.New("connection refused"),
+			want:     `message=failed metadata="connection refused"`,
+		},
+		{
+			name:    "Struct Metadata",
+			message: "lot",
+			metadata: lot{
+				Name:     "Lot A",
+				Spaces:   120,
+				Location: location{City: "Dallas"},
+				Gates:    []string{"north", "south"},
+			},
+			want: `message=lot metadata.gates.0=north metadata.gates.1=south metadata.location.city=Dallas metadata.name="Lot A" metadata.spaces=120`,
+		},
+		{
+			name:    "Request Log Metadata",
+			message: "GET /lots",
+			metadata: requestLog{
+				method:  "GET",
+				path:    "/lots",
+				status:  200,
+				latency: 1500 * time.Microsecond,
+			},
+			want: `message="GET /lots" metadata.latency=1500000 metadata.method=GET metadata.path=/lots metadata.status=200`,
+		},
+		{
+			name:     "Time Metadata",
+			message:  "time",
+			metadata: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:     `message=time metadata="2021-03-01 00:00:00 +0000 UTC"`,
+		},
+		{
+			name:     "Map Metadata",
+			message:  "map",
+			metadata: map[string]interface{}{"b key": true, "a": nil},
+			want:     `message=map metadata.a=null metadata.b_key=true`,
+		},
+	}
+
+	prefix := regexp.MustCompile(`^timestamp=\S+ level=INFO tags=test,logfmt file=logfmt_test.go:\d+ `)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewLogger(LoggerConfig{
+				Level:      LogLevelDebug,
+				Format:     LogFormatLogfmt,
+				TimeFormat: TimeFormatLoggly,
+				LogCaller:  true,
+				Tags:       []string{"test", "logfmt"},
+				Output:     &buf,
+			})
+			l.Infod(tt.message, tt.metadata)
+
+			line := strings.TrimSuffix(buf.String(), "\n")
+			p := prefix.FindString(line)
+			if p == "" {
+				t.Fatalf("unexpected prefix: %q", line)
+			}
+			if got := line[len(p):]; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package log
 
+import (
+	"strconv"
+	"time"
+)
+
 // Format visual format of the log message.
 type Format string
 
@@ -9,10 +14,86 @@ const (
 
 	// LogFormatJSON is a json formatted log output.
 	LogFormatJSON Format = "json"
+
+	// LogFormatLogfmt is a logfmt formatted log output of key=value pairs.
+	LogFormatLogfmt Format = "logfmt"
+
+	// LogFormatGoogleCloud is a json formatted log output using the special
+	// fields recognized by Google Cloud Logging.
+	LogFormatGoogleCloud Format = "google-cloud"
+
+	// LogFormatECS is a json formatted log output using Elastic Common Schema
+	// fields.
+	LogFormatECS Format = "ecs"
 )
 
+// builtin reports whether the format is one of the package's own formats
+func (f Format) builtin() bool {
+	switch f {
+	case LogFormatPretty, LogFormatJSON, LogFormatLogfmt, LogFormatGoogleCloud, LogFormatECS:
+		return true
+	}
+	return false
+}
+
+// TimeFormat format of the log message timestamp. Values other than the
+// constants below are used as a Go time layout, e.g. "2006-01-02 15:04:05".
 type TimeFormat string
 
 const (
+	// TimeFormatLoggly is an ISO 8601 timestamp in UTC with microseconds, as
+	// accepted by Loggly.
 	TimeFormatLoggly TimeFormat = "loggly"
+
+	// TimeFormatRFC3339 is an RFC 3339 timestamp with seconds.
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+
+	// TimeFormatRFC3339Nano is an RFC 3339 timestamp with nanoseconds. This is
+	// the default.
+	TimeFormatRFC3339Nano TimeFormat = "rfc3339nano"
+
+	// TimeFormatUnix is seconds since the Unix epoch, written as a number in
+	// JSON.
+	TimeFormatUnix TimeFormat = "unix"
+
+	// TimeFormatUnixMilli is milliseconds since the Unix epoch, written as a
+	// number in JSON.
+	TimeFormatUnixMilli TimeFormat = "unixmilli"
+
+	// TimeFormatUnixNano is nanoseconds since the Unix epoch, written as a
+	// number in JSON.
+	TimeFormatUnixNano TimeFormat = "unixnano"
 )
+
+// MARK: Methods
+
+// format returns t formatted as a timestamp
+func (f TimeFormat) format(t time.Time) string {
+	switch f {
+	case TimeFormatLoggly:
+		// According to loggly documentation:
+		// * The only timestamp format accepted is ISO 8601 (e.g., 2013-10-11T22:14:15.003Z).
+		// * Loggly supports microseconds/seconds fraction up to 6 digits, per the spec in RFC5424.
+		return t.UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+	case TimeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	case TimeFormatRFC3339Nano, "":
+		return t.Format(time.RFC3339Nano)
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	case TimeFormatUnixNano:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+	return t.Format(string(f))
+}
+
+// numeric reports whether timestamps in the format are numbers
+func (f TimeFormat) numeric() bool {
+	switch f {
+	case TimeFormatUnix, TimeFormatUnixMilli, TimeFormatUnixNano:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeFormat(t *testing.T) {
+	ts := time.Date(2021, 2, 27, 18, 8, 48, 749400123, time.UTC)
+
+	tests := []struct {
+		format TimeFormat
+		want   string
+	}{
+		{TimeFormatLoggly, "2021-02-27T18:08:48.749400Z"},
+		{TimeFormatRFC3339, "2021-02-27T18:08:48Z"},
+		{TimeFormatRFC3339Nano, "2021-02-27T18:08:48.749400123Z"},
+		{"", "2021-02-27T18:08:48.749400123Z"},
+		{TimeFormatUnix, "1614449328"},
+		{TimeFormatUnixMilli, "1614449328749"},
+		{TimeFormatUnixNano, "1614449328749400123"},
+		{"2006-01-02 15:04:05", "2021-02-27 18:08:48"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			if got := tt.format.format(ts); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("JSON Number", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			Format:     LogFormatJSON,
+			TimeFormat: TimeFormatUnixMilli,
+			Output:     &buf,
+		})
+		l.Infoln("hello")
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if _, ok := entry["timestamp"].(float64); !ok {
+			t.Errorf("expected numeric timestamp, got %q", buf.String())
+		}
+		if entry["message"] != "hello" || entry["level"] != "INFO" {
+			t.Errorf("unexpected entry: %v", entry)
+		}
+	})
+
+	t.Run("JSON String", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			Format:     LogFormatJSON,
+			TimeFormat: TimeFormatRFC3339,
+			Output:     &buf,
+		})
+		l.Infoln("hello")
+
+		var entry struct{ Timestamp string }
+		if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if _, err := time.Parse(time.RFC3339, entry.Timestamp); err != nil {
+			t.Errorf("invalid timestamp: %v", err)
+		}
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			TimeFormat: "15:04 MST",
+			Location:   time.FixedZone("XST", -6*60*60),
+			Output:     &buf,
+		})
+		l.Infoln("hello")
+
+		if fields := strings.Fields(buf.String()); len(fields) < 2 || fields[1] != "XST" {
+			t.Errorf("expected timestamp in location, got %q", buf.String())
+		}
+	})
+}
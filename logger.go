@@ -1,7 +1,11 @@
 package log
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"text/template"
 	"time"
 )
 
@@ -12,55 +16,189 @@ type Logger interface {
 	Logln(Level, string)
 	Logf(Level, string, ...interface{})
 	Logd(Level, string, interface{})
+	Logw(Level, string, ...interface{})
+	Logfn(Level, func() (string, interface{}))
+	Enabled(Level) bool
 
 	// Trace
 	Traceln(string)
 	Tracef(string, ...interface{})
 	Traced(string, interface{})
+	Tracew(string, ...interface{})
+	Tracefn(func() (string, interface{}))
 
 	// Debug
 	Debugln(string)
 	Debugf(string, ...interface{})
 	Debugd(string, interface{})
+	Debugw(string, ...interface{})
+	Debugfn(func() (string, interface{}))
 
 	// Info
 	Infoln(string)
 	Infof(string, ...interface{})
 	Infod(string, interface{})
+	Infow(string, ...interface{})
+	Infofn(func() (string, interface{}))
 
 	// Warn
 	Warnln(string)
 	Warnf(string, ...interface{})
 	Warnd(string, interface{})
+	Warnw(string, ...interface{})
+	Warnfn(func() (string, interface{}))
 
 	// Error
 	Errorln(string)
 	Errorf(string, ...interface{})
 	Errord(string, interface{})
+	Errorw(string, ...interface{})
+	Errorfn(func() (string, interface{}))
 
 	// Fatal
 	Fatalln(string)
 	Fatalf(string, ...interface{})
 	Fatald(string, interface{})
+	Fatalw(string, ...interface{})
+	Fatalfn(func() (string, interface{}))
 
 	// Create a logger object with additional tags
 	Sublogger(tags ...string) Logger
 
+	// Create a logger object with additional fields
+	With(fields ...Field) Logger
+	WithField(key string, value interface{}) Logger
+
+	// Context
+	LogCtx(context.Context, Level, string, ...interface{})
+	TraceCtx(context.Context, string, ...interface{})
+	DebugCtx(context.Context, string, ...interface{})
+	InfoCtx(context.Context, string, ...interface{})
+	WarnCtx(context.Context, string, ...interface{})
+	ErrorCtx(context.Context, string, ...interface{})
+	FatalCtx(context.Context, string, ...interface{})
+
+	// Events
+	Trace() *Event
+	Debug() *Event
+	Info() *Event
+	Warn() *Event
+	Error() *Event
+	Fatal() *Event
+
+	// Lifecycle
+	Flush(ctx context.Context) error
+	Close(ctx context.Context) error
+
 	// Private methods
 	newLogMessage(message string, level Level, skipOffset int, data interface{}) *logMessage
 	level() Level
+	write(*logMessage)
 	exit()
 }
 
+// LoggerConfig defines the options used to create a Logger
+type LoggerConfig struct {
+	Level      Level
+	Format     Format
+	TimeFormat TimeFormat
+	Colorize   bool
+	LogCaller  bool
+	Tags       []string
+
+	// Output is the destination for log messages. Writes to it are serialized,
+	// so it does not need to be safe for concurrent use. Defaults to os.Stdout.
+	Output io.Writer
+
+	// Async, if set, writes to Output from a background goroutine instead of
+	// the logging goroutine.
+	Async *AsyncConfig
+
+	// Sinks are destinations that each have their own level, format and
+	// colorization. If any are provided, Level, Format, Colorize, Output and
+	// Async are ignored and each message is written to every sink whose level
+	// it meets.
+	Sinks []Sink
+
+	// FlushTimeout is how long Fatal logs wait for outputs to be flushed before
+	// exiting. Defaults to five seconds.
+	FlushTimeout time.Duration
+
+	// ECSNamespace is the field that metadata is nested under in
+	// LogFormatECS output. Defaults to "metadata".
+	ECSNamespace string
+
+	// Location is the time zone that timestamps are formatted in. Defaults to
+	// the local time zone. TimeFormatLoggly timestamps are always in UTC.
+	Location *time.Location
+
+	// PrettyTemplate is a text/template layout for LogFormatPretty output.
+	// When empty, or when it fails to parse, the default layout is used.
+	PrettyTemplate string
+
+	// FieldNames renames, reorders or inlines the fields of LogFormatJSON
+	// output.
+	FieldNames FieldNames
+}
+
+// Sink defines a destination for log messages along with the minimum level,
+// format and colorization used for it
+type Sink struct {
+	Output   io.Writer
+	Level    Level
+	Format   Format
+	Colorize bool
+
+	// Async, if set, writes to Output from a background goroutine instead of
+	// the logging goroutine.
+	Async *AsyncConfig
+}
+
 // logger is the basic Logger implementation
 type logger struct {
 	rawLevel       Level
 	format         Format
 	timeFormat     TimeFormat
+	location       *time.Location
 	tags           []string
 	colorizeOutput bool
 	logCaller      bool
+	output         io.Writer
+	sinks          []*sink
+	async          []*asyncWriter
+	flushTimeout   time.Duration
+	options        formatOptions
 	exitFunc       func()
+	messages       messageWriter
+}
+
+// messageWriter writes messages in place of a logger's outputs, for loggers
+// that forward to another logging library
+type messageWriter interface {
+	writeMessage(m *logMessage)
+}
+
+// formatOptions are the logger's options that only affect some formats
+type formatOptions struct {
+	ecsNamespace   string
+	prettyTemplate *template.Template
+	jsonLayout     *jsonLayout
+}
+
+// sink is a configured Sink with serialized or asynchronous writes
+type sink struct {
+	output   io.Writer
+	level    Level
+	format   Format
+	colorize bool
+}
+
+// MARK: Public Functions
+
+// NewLogger returns a Logger configured with the provided options. Unlike
+// SetupLogger, it does not affect the default logger.
+func NewLogger(config LoggerConfig) Logger {
+	return newLogger(config)
 }
 
 // MARK: Public Methods
@@ -68,32 +206,305 @@ type logger struct {
 // Sublogger returns a new sublogger on the logger with the provided tags
 func (l *logger) Sublogger(tags ...string) Logger {
 	return &sublogger{
-		Logger:  l,
-		subTags: tags,
+		Logger:     l,
+		subTags:    tags,
+		skipOffset: 1,
+	}
+}
+
+// With returns a new sublogger on the logger that logs the provided fields
+// with every message
+func (l *logger) With(fields ...Field) Logger {
+	return &sublogger{
+		Logger:     l,
+		fields:     fields,
+		skipOffset: 1,
+	}
+}
+
+// WithField returns a new sublogger on the logger that logs the provided key
+// and value with every message
+func (l *logger) WithField(key string, value interface{}) Logger {
+	return l.With(Field{Key: key, Value: value})
+}
+
+// Flush waits for every message logged before the call to be written to the
+// logger's outputs, and flushes outputs that buffer messages, until the context
+// is done.
+func (l *logger) Flush(ctx context.Context) error {
+	var err error
+	for _, w := range l.outputs() {
+		if e := flushOutput(ctx, w); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Close flushes the logger and closes its outputs that can be closed, other
+// than the process's standard output and error. Messages logged after Close are
+// still written to the outputs that weren't closed, such as the standard
+// output, and are discarded by closed outputs, such as asynchronous ones.
+func (l *logger) Close(ctx context.Context) error {
+	err := l.Flush(ctx)
+	for _, w := range l.outputs() {
+		if e := closeOutput(ctx, w); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// MARK: Context
+
+// LogCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) LogCtx(ctx context.Context, level Level, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, level, message, keysAndValues)
+}
+
+// TraceCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) TraceCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelTrace, message, keysAndValues)
+}
+
+// DebugCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) DebugCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelDebug, message, keysAndValues)
+}
+
+// InfoCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) InfoCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelInfo, message, keysAndValues)
+}
+
+// WarnCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) WarnCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelWarn, message, keysAndValues)
+}
+
+// ErrorCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (l *logger) ErrorCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelError, message, keysAndValues)
+}
+
+// FatalCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values, and calls os.Exit(1)
+func (l *logger) FatalCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(l, ctx, LogLevelFatal, message, keysAndValues)
+	l.exit()
+}
+
+// MARK: Events
+
+// Trace starts a trace level event
+func (l *logger) Trace() *Event {
+	return newEvent(l, LogLevelTrace)
+}
+
+// Debug starts a debug level event
+func (l *logger) Debug() *Event {
+	return newEvent(l, LogLevelDebug)
+}
+
+// Info starts an info level event
+func (l *logger) Info() *Event {
+	return newEvent(l, LogLevelInfo)
+}
+
+// Warn starts a warn level event
+func (l *logger) Warn() *Event {
+	return newEvent(l, LogLevelWarn)
+}
+
+// Error starts an error level event
+func (l *logger) Error() *Event {
+	return newEvent(l, LogLevelError)
+}
+
+// Fatal starts a fatal level event. Writing the event calls os.Exit(1).
+func (l *logger) Fatal() *Event {
+	return newEvent(l, LogLevelFatal)
+}
+
+// MARK: Private Functions
+
+// newLogger creates a *logger from a LoggerConfig
+func newLogger(config LoggerConfig) *logger {
+	l := &logger{
+		rawLevel:       config.Level,
+		format:         config.Format,
+		timeFormat:     config.TimeFormat,
+		location:       config.Location,
+		colorizeOutput: config.Colorize,
+		logCaller:      config.LogCaller,
+		tags:           config.Tags,
+		flushTimeout:   config.FlushTimeout,
+		exitFunc:       func() { os.Exit(1) },
 	}
+	l.setOutputs(config)
+	l.setOptions(config)
+	return l
 }
 
 // MARK: Private Methods
 
+// now returns the current time in the logger's location
+func (l *logger) now() time.Time {
+	if l.location == nil {
+		return time.Now()
+	}
+	return time.Now().In(l.location)
+}
+
+// setOptions replaces the logger's format options. A PrettyTemplate that fails
+// to parse is logged as an error and the default pretty layout is used.
+func (l *logger) setOptions(config LoggerConfig) {
+	options := formatOptions{
+		ecsNamespace: config.ECSNamespace,
+		jsonLayout:   newJSONLayout(config.FieldNames),
+	}
+
+	var err error
+	if config.PrettyTemplate != "" {
+		options.prettyTemplate, err = parsePrettyTemplate(config.PrettyTemplate)
+	}
+	l.options = options
+
+	if err != nil {
+		l.Errord("invalid pretty template, using default layout", err)
+	}
+}
+
+// setOutputs replaces the logger's output and sinks, closing any asynchronous
+// outputs they replace. The logger's level becomes the lowest level of any sink
+// so that messages are only filtered out when no sink would write them.
+func (l *logger) setOutputs(config LoggerConfig) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.fatalFlushTimeout())
+	defer cancel()
+	for _, aw := range l.async {
+		_ = aw.close(ctx)
+	}
+	l.async = nil
+
+	l.output = nil
+	l.sinks = nil
+	if len(config.Sinks) == 0 {
+		l.output = l.newOutput(config.Output, config.Async)
+		return
+	}
+
+	l.sinks = make([]*sink, len(config.Sinks))
+	for i, s := range config.Sinks {
+		l.sinks[i] = &sink{
+			output:   l.newOutput(s.Output, s.Async),
+			level:    s.Level,
+			format:   s.Format,
+			colorize: s.Colorize,
+		}
+		if i == 0 || s.Level < l.rawLevel {
+			l.rawLevel = s.Level
+		}
+	}
+}
+
+// newOutput returns a writer for w that serializes writes, or that writes
+// asynchronously if async is set
+func (l *logger) newOutput(w io.Writer, async *AsyncConfig) io.Writer {
+	if async == nil {
+		if w == nil {
+			return stdout
+		}
+		return newSyncWriter(w)
+	}
+
+	if w == nil {
+		w = stdout
+	}
+	aw := newAsyncWriter(w, *async)
+	l.async = append(l.async, aw)
+	go l.reportDrops(aw)
+	return aw
+}
+
+// outputs returns the writers the logger writes its messages to
+func (l *logger) outputs() []io.Writer {
+	if l.sinks == nil {
+		if l.output == nil {
+			return []io.Writer{stdout}
+		}
+		return []io.Writer{l.output}
+	}
+
+	outputs := make([]io.Writer, len(l.sinks))
+	for i, s := range l.sinks {
+		outputs[i] = s.output
+	}
+	return outputs
+}
+
+// fatalFlushTimeout returns how long to wait for outputs to be flushed before
+// exiting
+func (l *logger) fatalFlushTimeout() time.Duration {
+	if l.flushTimeout <= 0 {
+		return 5 * time.Second
+	}
+	return l.flushTimeout
+}
+
+// reportDrops periodically logs the number of lines dropped by an asynchronous
+// output until it is closed. Reports are written to every output whatever its
+// level, and without a caller, since the caller would be this goroutine.
+func (l *logger) reportDrops(aw *asyncWriter) {
+	ticker := time.NewTicker(aw.reportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-aw.done:
+			return
+		case <-ticker.C:
+			if n := aw.takeDropped(); n > 0 {
+				m := l.newLogMessage("dropped log lines", LogLevelWarn, 0, map[string]uint64{"dropped": n})
+				m.logCaller = false
+				m.File = ""
+				l.writeSinks(m, true)
+			}
+		}
+	}
+}
+
 // level returns the Logger's Level
 func (l *logger) level() Level {
 	return l.rawLevel
 }
 
+// callerDepth is the number of frames between a logger's newLogMessage and
+// the caller of the method that logged the message. Every method reaches
+// newLogMessage through printMessage and one base log implementation, and each
+// sublogger adds a frame, which its skipOffset accounts for.
+const callerDepth = 4
+
 // newLogMessage creates a new logMessage
 func (l *logger) newLogMessage(output string, level Level, skipOffset int, d interface{}) *logMessage {
-	return newLogMessage(
+	m := newLogMessage(
 		l.format,
 		l.colorizeOutput,
 		l.logCaller,
-		5+skipOffset,
-		time.Now(),
+		callerDepth+skipOffset,
+		l.now(),
 		l.timeFormat,
 		level,
 		l.tags,
 		output,
 		d,
 	)
+	m.options = l.options
+	return m
 }
 
 // printMessage prints the message with the given output, level and data. If
@@ -103,22 +514,99 @@ func (l *logger) printMessage(output string, level Level, d interface{}) {
 		return
 	}
 
-	fmt.Println(l.newLogMessage(output, level, 0, d).String())
+	l.write(l.newLogMessage(output, level, 0, d))
 }
 
+// write writes the message followed by a newline to the logger's output, or to
+// each of its sinks whose level the message meets. A logger that forwards
+// messages writes them to its messageWriter instead.
+func (l *logger) write(m *logMessage) {
+	l.writeSinks(m, false)
+}
+
+// writeSinks writes the message like write, to every sink if all is set
+func (l *logger) writeSinks(m *logMessage, all bool) {
+	if l.messages != nil {
+		l.messages.writeMessage(m)
+		return
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if l.sinks == nil {
+		w := l.output
+		if w == nil {
+			w = stdout
+		}
+		*buf = append(m.appendRender(*buf, m.format, m.colorize), '\n')
+		_, _ = writeLevel(w, m.rawLevel, m.Tags, *buf)
+		return
+	}
+
+	for _, s := range l.sinks {
+		if s.level > m.rawLevel && !all {
+			continue
+		}
+		*buf = append(m.appendRender((*buf)[:0], s.format, s.colorize), '\n')
+		_, _ = writeLevel(s.output, m.rawLevel, m.Tags, *buf)
+	}
+}
+
+// exit flushes the logger's outputs, waiting up to its flush timeout, then
+// calls its exit function
 func (l *logger) exit() {
+	ctx, cancel := context.WithTimeout(context.Background(), l.fatalFlushTimeout())
+	_ = l.Flush(ctx)
+	cancel()
 	l.exitFunc()
 }
 
+// Enabled reports whether messages at the level are logged
+func (l *logger) Enabled(level Level) bool {
+	return l.rawLevel <= level
+}
+
 // MARK: base log methods
 
 // Logln prints the output followed by a newline
 func (l *logger) Logln(level Level, message string) {
-	l.printMessage(message, level, nil)
+	l.logln(level, message)
 }
 
 // Logf prints the formatted output
 func (l *logger) Logf(level Level, format string, a ...interface{}) {
+	l.logf(level, format, a...)
+}
+
+// Logd prints output string and data
+func (l *logger) Logd(level Level, message string, d interface{}) {
+	l.logd(level, message, d)
+}
+
+// Logfn prints the output string and data returned by fn, which is only called
+// if the level is enabled
+func (l *logger) Logfn(level Level, fn func() (string, interface{})) {
+	l.logfn(level, fn)
+}
+
+// Logw prints output string and fields from alternating keys and values
+func (l *logger) Logw(level Level, message string, keysAndValues ...interface{}) {
+	l.logw(level, message, keysAndValues...)
+}
+
+// MARK: base log implementations
+
+// logln implements Logln and the *ln level methods
+func (l *logger) logln(level Level, message string) {
+	l.printMessage(message, level, nil)
+}
+
+// logf implements Logf and the *f level methods
+func (l *logger) logf(level Level, format string, a ...interface{}) {
+	if l.rawLevel > level {
+		return
+	}
 	l.printMessage(
 		fmt.Sprintf(format, a...),
 		level,
@@ -126,112 +614,197 @@ func (l *logger) Logf(level Level, format string, a ...interface{}) {
 	)
 }
 
-// Logd prints output string and data
-func (l *logger) Logd(level Level, message string, d interface{}) {
+// logd implements Logd and the *d level methods
+func (l *logger) logd(level Level, message string, d interface{}) {
 	l.printMessage(message, level, d)
 }
 
+// logfn implements Logfn and the *fn level methods
+func (l *logger) logfn(level Level, fn func() (string, interface{})) {
+	if l.rawLevel > level {
+		return
+	}
+	message, d := fn()
+	l.printMessage(message, level, d)
+}
+
+// logw implements Logw and the *w level methods
+func (l *logger) logw(level Level, message string, keysAndValues ...interface{}) {
+	if l.rawLevel > level {
+		return
+	}
+	l.printMessage(message, level, keyValueFields(keysAndValues))
+}
+
 // MARK: Trace
 
 // Traceln prints the output followed by a newline
 func (l *logger) Traceln(message string) {
-	l.Logln(LogLevelTrace, message)
+	l.logln(LogLevelTrace, message)
 }
 
 // Tracef prints the formatted output
 func (l *logger) Tracef(format string, a ...interface{}) {
-	l.Logf(LogLevelTrace, format, a...)
+	l.logf(LogLevelTrace, format, a...)
 }
 
 // Traced prints the output string and data
 func (l *logger) Traced(message string, d interface{}) {
-	l.Logd(LogLevelTrace, message, d)
+	l.logd(LogLevelTrace, message, d)
+}
+
+// Tracew prints the output string and fields from alternating keys and values
+func (l *logger) Tracew(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelTrace, message, keysAndValues...)
+}
+
+// Tracefn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Tracefn(fn func() (string, interface{})) {
+	l.logfn(LogLevelTrace, fn)
 }
 
 // MARK: Debug
 
 // Debugln prints the output followed by a newline
 func (l *logger) Debugln(message string) {
-	l.Logln(LogLevelDebug, message)
+	l.logln(LogLevelDebug, message)
 }
 
 // Debugf prints the formatted output
 func (l *logger) Debugf(format string, a ...interface{}) {
-	l.Logf(LogLevelDebug, format, a...)
+	l.logf(LogLevelDebug, format, a...)
 }
 
 // Debugd prints the output string and data
 func (l *logger) Debugd(message string, d interface{}) {
-	l.Logd(LogLevelDebug, message, d)
+	l.logd(LogLevelDebug, message, d)
+}
+
+// Debugw prints the output string and fields from alternating keys and values
+func (l *logger) Debugw(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelDebug, message, keysAndValues...)
+}
+
+// Debugfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Debugfn(fn func() (string, interface{})) {
+	l.logfn(LogLevelDebug, fn)
 }
 
 // MARK: Info
 
 // Infoln prints the output followed by a newline
 func (l *logger) Infoln(message string) {
-	l.Logln(LogLevelInfo, message)
+	l.logln(LogLevelInfo, message)
 }
 
 // Infof prints the formatted output
 func (l *logger) Infof(format string, a ...interface{}) {
-	l.Logf(LogLevelInfo, format, a...)
+	l.logf(LogLevelInfo, format, a...)
 }
 
 // Infod prints the output string and data
 func (l *logger) Infod(message string, d interface{}) {
-	l.Logd(LogLevelInfo, message, d)
+	l.logd(LogLevelInfo, message, d)
+}
+
+// Infow prints the output string and fields from alternating keys and values
+func (l *logger) Infow(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelInfo, message, keysAndValues...)
+}
+
+// Infofn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Infofn(fn func() (string, interface{})) {
+	l.logfn(LogLevelInfo, fn)
 }
 
 // MARK: Warn
 
 // Warnln prints the output followed by a newline
 func (l *logger) Warnln(message string) {
-	l.Logln(LogLevelWarn, message)
+	l.logln(LogLevelWarn, message)
 }
 
 // Warnf prints the formatted output
 func (l *logger) Warnf(format string, a ...interface{}) {
-	l.Logf(LogLevelWarn, format, a...)
+	l.logf(LogLevelWarn, format, a...)
 }
 
 // Warnd prints the output string and data
 func (l *logger) Warnd(message string, d interface{}) {
-	l.Logd(LogLevelWarn, message, d)
+	l.logd(LogLevelWarn, message, d)
+}
+
+// Warnw prints the output string and fields from alternating keys and values
+func (l *logger) Warnw(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelWarn, message, keysAndValues...)
+}
+
+// Warnfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Warnfn(fn func() (string, interface{})) {
+	l.logfn(LogLevelWarn, fn)
 }
 
 // MARK: Error
 
 // Errorln prints the output followed by a newline
 func (l *logger) Errorln(message string) {
-	l.Logln(LogLevelError, message)
+	l.logln(LogLevelError, message)
 }
 
 // Errorf prints the formatted output
 func (l *logger) Errorf(format string, a ...interface{}) {
-	l.Logf(LogLevelError, format, a...)
+	l.logf(LogLevelError, format, a...)
 }
 
 // Errord prints the output string and data
 func (l *logger) Errord(message string, d interface{}) {
-	l.Logd(LogLevelError, message, d)
+	l.logd(LogLevelError, message, d)
+}
+
+// Errorw prints the output string and fields from alternating keys and values
+func (l *logger) Errorw(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelError, message, keysAndValues...)
+}
+
+// Errorfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Errorfn(fn func() (string, interface{})) {
+	l.logfn(LogLevelError, fn)
 }
 
 // MARK: Fatal
 
 // Fatalln prints the output followed by a newline
 func (l *logger) Fatalln(message string) {
-	l.Logln(LogLevelFatal, message)
-	l.exitFunc()
+	l.logln(LogLevelFatal, message)
+	l.exit()
 }
 
 // Fatalf prints the formatted output
 func (l *logger) Fatalf(format string, a ...interface{}) {
-	l.Logf(LogLevelFatal, format, a...)
-	l.exitFunc()
+	l.logf(LogLevelFatal, format, a...)
+	l.exit()
 }
 
 // Fatald prints the output string and data
 func (l *logger) Fatald(message string, d interface{}) {
-	l.Logd(LogLevelFatal, message, d)
-	l.exitFunc()
+	l.logd(LogLevelFatal, message, d)
+	l.exit()
+}
+
+// Fatalw prints the output string and fields from alternating keys and values
+func (l *logger) Fatalw(message string, keysAndValues ...interface{}) {
+	l.logw(LogLevelFatal, message, keysAndValues...)
+	l.exit()
+}
+
+// Fatalfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (l *logger) Fatalfn(fn func() (string, interface{})) {
+	l.logfn(LogLevelFatal, fn)
+	l.exit()
 }
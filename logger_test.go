@@ -0,0 +1,204 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MARK: Test Types
+
+// closeRecorder is a syncBuffer that records whether it was closed
+type closeRecorder struct {
+	syncBuffer
+	closed bool
+}
+
+func (cr *closeRecorder) Close() error {
+	cr.closed = true
+	return nil
+}
+
+// MARK: Tests
+
+func TestCaller(t *testing.T) {
+	source, err := os.ReadFile("logger_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(source), "\n")
+	file := regexp.MustCompile(`file=logger_test\.go:(\d+) `)
+
+	calls := []struct {
+		name string
+		log  func(l Logger)
+	}{
+		{"Logln", func(l Logger) { l.Logln(LogLevelInfo, "m") }},
+		{"Logf", func(l Logger) { l.Logf(LogLevelInfo, "%s", "m") }},
+		{"Logd", func(l Logger) { l.Logd(LogLevelInfo, "m", 1) }},
+		{"Logw", func(l Logger) { l.Logw(LogLevelInfo, "m", "k", 1) }},
+		{"Logfn", func(l Logger) { l.Logfn(LogLevelInfo, func() (string, interface{}) { return "m", nil }) }},
+		{"Infoln", func(l Logger) { l.Infoln("m") }},
+		{"Infof", func(l Logger) { l.Infof("%s", "m") }},
+		{"Infod", func(l Logger) { l.Infod("m", 1) }},
+		{"Infow", func(l Logger) { l.Infow("m", "k", 1) }},
+		{"Infofn", func(l Logger) { l.Infofn(func() (string, interface{}) { return "m", nil }) }},
+		{"Info", func(l Logger) { l.Info().Msg("m") }},
+		{"InfoCtx", func(l Logger) { l.InfoCtx(context.Background(), "m") }},
+	}
+	packageCalls := []struct {
+		name string
+		log  func()
+	}{
+		{"Logln", func() { Logln(LogLevelInfo, "m") }},
+		{"Logd", func() { Logd(LogLevelInfo, "m", 1) }},
+		{"Logw", func() { Logw(LogLevelInfo, "m", "k", 1) }},
+		{"Logfn", func() { Logfn(LogLevelInfo, func() (string, interface{}) { return "m", nil }) }},
+		{"Infoln", func() { Infoln("m") }},
+		{"Infof", func() { Infof("%s", "m") }},
+		{"Infow", func() { Infow("m", "k", 1) }},
+		{"Info", func() { Info().Msg("m") }},
+		{"InfoCtx", func() { InfoCtx(context.Background(), "m") }},
+	}
+
+	// check asserts that the message was logged from a line that makes the call
+	check := func(t *testing.T, buf *syncBuffer, name string) {
+		t.Helper()
+		match := file.FindStringSubmatch(buf.String())
+		if match == nil {
+			t.Fatalf("no caller in %q", buf.String())
+		}
+		n, _ := strconv.Atoi(match[1])
+		if n < 1 || n > len(lines) || !strings.Contains(lines[n-1], name+"(") {
+			t.Errorf("%s logged caller logger_test.go:%s", name, match[1])
+		}
+	}
+
+	loggers := []struct {
+		name string
+		new  func(config LoggerConfig) Logger
+	}{
+		{"Logger", NewLogger},
+		{"Sublogger", func(c LoggerConfig) Logger { return NewLogger(c).Sublogger("a") }},
+		{"Nested Sublogger", func(c LoggerConfig) Logger { return NewLogger(c).Sublogger("a").Sublogger("b") }},
+		{"With", func(c LoggerConfig) Logger { return NewLogger(c).With(Int("a", 1)).WithField("b", 2) }},
+	}
+	for _, lt := range loggers {
+		for _, call := range calls {
+			t.Run(lt.name+" "+call.name, func(t *testing.T) {
+				var buf syncBuffer
+				call.log(lt.new(LoggerConfig{Format: LogFormatLogfmt, LogCaller: true, Output: &buf}))
+				check(t, &buf, call.name)
+			})
+		}
+	}
+
+	for _, call := range packageCalls {
+		t.Run("Package "+call.name, func(t *testing.T) {
+			var buf syncBuffer
+			SetupLoggerWithConfig(LoggerConfig{Format: LogFormatLogfmt, LogCaller: true, Output: &buf})
+			defer SetupLocalLogger(LogLevelDebug)
+
+			call.log()
+			check(t, &buf, call.name)
+		})
+	}
+	for _, call := range calls {
+		t.Run("Package Sublogger "+call.name, func(t *testing.T) {
+			var buf syncBuffer
+			SetupLoggerWithConfig(LoggerConfig{Format: LogFormatLogfmt, LogCaller: true, Output: &buf})
+			defer SetupLocalLogger(LogLevelDebug)
+
+			call.log(Sublogger("a"))
+			check(t, &buf, call.name)
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Run("Flush", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Output: &buf,
+			Async:  &AsyncConfig{QueueSize: 1000},
+		})
+
+		for i := 0; i < 500; i++ {
+			l.Infoln("This is an info statement.")
+		}
+		if err := l.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected flush error: %v", err)
+		}
+		if n := strings.Count(buf.String(), "\n"); n != 500 {
+			t.Errorf("expected 500 lines after flush, got %d", n)
+		}
+	})
+
+	t.Run("Flush Deadline", func(t *testing.T) {
+		gw := newGatedWriter()
+		defer close(gw.gate)
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Output: gw,
+			Async:  &AsyncConfig{},
+		})
+
+		l.Infoln("This is stuck.")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := l.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		var out closeRecorder
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Output: &out,
+			Async:  &AsyncConfig{},
+		})
+
+		l.Infoln("This is an info statement.")
+		if err := l.Close(context.Background()); err != nil {
+			t.Fatalf("unexpected close error: %v", err)
+		}
+		if !out.closed {
+			t.Error("output was not closed")
+		}
+		if !strings.Contains(out.String(), "This is an info statement.") {
+			t.Errorf("message was not written before close: %q", out.String())
+		}
+	})
+
+	t.Run("Fatal Flushes", func(t *testing.T) {
+		gw := newGatedWriter()
+		l := newLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Output: gw,
+			Async:  &AsyncConfig{},
+		})
+		var exitLines []string
+		l.exitFunc = func() { exitLines = gw.lines() }
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(gw.gate)
+		}()
+		l.Sublogger("sub").Fatalln("This is a fatal ln.")
+
+		if len(exitLines) != 1 || !strings.Contains(exitLines[0], "This is a fatal ln.") {
+			t.Errorf("fatal message was not flushed before exit: %v", exitLines)
+		}
+	})
+}
@@ -0,0 +1,340 @@
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MARK: Types
+
+// LogglyConfig defines the options for a LogglyWriter
+type LogglyConfig struct {
+	// Token is the Loggly customer token.
+	Token string
+
+	// Endpoint is the URL of the bulk endpoint, without the token. Defaults to
+	// "https://logs-01.loggly.com/bulk/".
+	Endpoint string
+
+	// Client defaults to an http.Client with a 30 second timeout.
+	Client *http.Client
+
+	// BatchSize is the number of lines that triggers sending a batch. Defaults
+	// to 100.
+	BatchSize int
+
+	// FlushInterval is how often buffered lines are sent regardless of the
+	// batch size. Defaults to five seconds.
+	FlushInterval time.Duration
+
+	// Gzip compresses request bodies.
+	Gzip bool
+
+	// MaxRetries is the number of times a failed batch is retried before it is
+	// dropped. Defaults to three; a negative value disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the wait before the first retry, which doubles for each
+	// retry after it. Defaults to one second.
+	RetryBackoff time.Duration
+
+	// MaxBufferBytes is the most memory used by lines waiting to be sent. Lines
+	// written while the buffer is full are dropped. Defaults to 8 MiB.
+	MaxBufferBytes int
+}
+
+// LogglyWriter is an io.Writer that ships log lines in batches to the Loggly
+// bulk endpoint, sending the tags of each line as Loggly tags. Lines should be
+// JSON formatted with TimeFormatLoggly so that Loggly can parse them.
+type LogglyWriter struct {
+	config LogglyConfig
+
+	mu       sync.Mutex
+	batches  map[string]*logglyBatch
+	pending  int
+	buffered int
+	closed   bool
+
+	dropped uint64
+	send    chan struct{}
+	flushes chan chan error
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// logglyBatch is the lines waiting to be sent with the same tags
+type logglyBatch struct {
+	tags  []string
+	lines [][]byte
+	bytes int
+}
+
+// MARK: Public Functions
+
+// NewLogglyWriter returns a LogglyWriter and starts sending batches in the
+// background
+func NewLogglyWriter(config LogglyConfig) (*LogglyWriter, error) {
+	if config.Token == "" {
+		return nil, errors.New("loggly: token is required")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://logs-01.loggly.com/bulk/"
+	}
+	if !strings.HasSuffix(config.Endpoint, "/") {
+		config.Endpoint += "/"
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = time.Second
+	}
+	if config.MaxBufferBytes <= 0 {
+		config.MaxBufferBytes = 8 << 20
+	}
+
+	lw := &LogglyWriter{
+		config:  config,
+		batches: map[string]*logglyBatch{},
+		send:    make(chan struct{}, 1),
+		flushes: make(chan chan error),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go lw.run()
+	return lw, nil
+}
+
+// MARK: Public Methods
+
+// Write buffers p to be sent without tags
+func (lw *LogglyWriter) Write(p []byte) (int, error) {
+	return lw.WriteLevel(logLevelUnset, nil, p)
+}
+
+// WriteLevel buffers p to be sent with the given tags. The line is dropped if
+// the buffer is full.
+func (lw *LogglyWriter) WriteLevel(level Level, tags []string, p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if lw.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if lw.buffered+len(p) > lw.config.MaxBufferBytes {
+		atomic.AddUint64(&lw.dropped, 1)
+		return len(p), nil
+	}
+
+	key := strings.Join(tags, ",")
+	b, ok := lw.batches[key]
+	if !ok {
+		b = &logglyBatch{tags: append([]string(nil), tags...)}
+		lw.batches[key] = b
+	}
+	line := make([]byte, len(p))
+	copy(line, p)
+	b.lines = append(b.lines, line)
+	b.bytes += len(line)
+	lw.buffered += len(line)
+	lw.pending++
+
+	if lw.pending >= lw.config.BatchSize {
+		select {
+		case lw.send <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends every buffered line, returning the first error from sending a
+// batch or the context's error if it is done first
+func (lw *LogglyWriter) Flush(ctx context.Context) error {
+	result := make(chan error, 1)
+	select {
+	case lw.flushes <- result:
+	case <-lw.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends every buffered line and stops the background goroutine. Lines
+// written after Close are rejected.
+func (lw *LogglyWriter) Close() error {
+	lw.mu.Lock()
+	if lw.closed {
+		lw.mu.Unlock()
+		return nil
+	}
+	lw.closed = true
+	lw.mu.Unlock()
+
+	close(lw.done)
+	<-lw.stopped
+	return nil
+}
+
+// Dropped returns the number of lines dropped because the buffer was full or
+// they couldn't be sent
+func (lw *LogglyWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&lw.dropped)
+}
+
+// MARK: Private Methods
+
+// run sends batches when the batch size is reached, the flush interval elapses
+// or a flush is requested, until the writer is closed
+func (lw *LogglyWriter) run() {
+	defer close(lw.stopped)
+	ticker := time.NewTicker(lw.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = lw.sendAll()
+		case <-lw.send:
+			_ = lw.sendAll()
+		case result := <-lw.flushes:
+			result <- lw.sendAll()
+		case <-lw.done:
+			_ = lw.sendAll()
+			return
+		}
+	}
+}
+
+// sendAll sends every buffered batch, returning the first error
+func (lw *LogglyWriter) sendAll() error {
+	lw.mu.Lock()
+	batches := lw.batches
+	lw.batches = map[string]*logglyBatch{}
+	lw.pending = 0
+	lw.mu.Unlock()
+
+	var err error
+	for _, b := range batches {
+		// Loggly limits bulk requests to 5MB, so very large batches are split
+		for start := 0; start < len(b.lines); {
+			end, size := start, 0
+			for end < len(b.lines) && (end == start || size+len(b.lines[end]) <= 5<<20) {
+				size += len(b.lines[end])
+				end++
+			}
+			if e := lw.sendBatch(b.tags, b.lines[start:end]); e != nil {
+				atomic.AddUint64(&lw.dropped, uint64(end-start))
+				if err == nil {
+					err = e
+				}
+			}
+			start = end
+		}
+
+		lw.mu.Lock()
+		lw.buffered -= b.bytes
+		lw.mu.Unlock()
+	}
+	return err
+}
+
+// sendBatch posts lines to the bulk endpoint, retrying with backoff on network
+// errors, rate limiting and server errors
+func (lw *LogglyWriter) sendBatch(tags []string, lines [][]byte) error {
+	var body bytes.Buffer
+	if lw.config.Gzip {
+		gz := gzip.NewWriter(&body)
+		for _, line := range lines {
+			_, _ = gz.Write(line)
+		}
+		_ = gz.Close()
+	} else {
+		for _, line := range lines {
+			body.Write(line)
+		}
+	}
+
+	backoff := lw.config.RetryBackoff
+	var err error
+	for attempt := 0; attempt <= lw.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = lw.post(tags, body.Bytes())
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// post makes a single bulk request, returning whether it should be retried if
+// it fails
+func (lw *LogglyWriter) post(tags []string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, lw.url(tags), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if lw.config.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	res, err := lw.config.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("loggly: bulk request failed with status %d", res.StatusCode)
+}
+
+// url returns the bulk endpoint URL for lines with the given tags
+func (lw *LogglyWriter) url(tags []string) string {
+	u := lw.config.Endpoint + url.PathEscape(lw.config.Token) + "/"
+	if len(tags) == 0 {
+		return u
+	}
+	escaped := make([]string, len(tags))
+	for i, tag := range tags {
+		escaped[i] = url.PathEscape(tag)
+	}
+	return u + "tag/" + strings.Join(escaped, ",") + "/"
+}
@@ -0,0 +1,225 @@
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MARK: Test Types
+
+// logglyServer records the bulk requests it receives
+type logglyServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []logglyRequest
+	failures int
+}
+
+type logglyRequest struct {
+	path  string
+	lines []string
+}
+
+func newLogglyServer(t *testing.T, failures int) *logglyServer {
+	ls := &logglyServer{failures: failures}
+	ls.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ls.mu.Lock()
+		defer ls.mu.Unlock()
+		if ls.failures > 0 {
+			ls.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("invalid gzip body: %v", err)
+				return
+			}
+			body = gz
+		}
+		b, _ := io.ReadAll(body)
+		ls.requests = append(ls.requests, logglyRequest{
+			path:  r.URL.Path,
+			lines: strings.Split(strings.TrimSuffix(string(b), "\n"), "\n"),
+		})
+		_, _ = w.Write([]byte(`{"response":"ok"}`))
+	}))
+	return ls
+}
+
+func (ls *logglyServer) received() []logglyRequest {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return append([]logglyRequest(nil), ls.requests...)
+}
+
+// MARK: Tests
+
+func TestLogglyWriter(t *testing.T) {
+	t.Run("Flush With Tags", func(t *testing.T) {
+		ls := newLogglyServer(t, 0)
+		defer ls.Close()
+
+		lw, err := NewLogglyWriter(LogglyConfig{
+			Token:         "token",
+			Endpoint:      ls.URL + "/bulk",
+			FlushInterval: time.Hour,
+			Gzip:          true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			Format:     LogFormatJSON,
+			TimeFormat: TimeFormatLoggly,
+			Tags:       []string{"some-api", "develop"},
+			Output:     lw,
+		})
+
+		l.Infoln("first")
+		l.Infoln("second")
+		l.Sublogger("requests").Debugln("third")
+		if err := l.Close(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		requests := ls.received()
+		if len(requests) != 2 {
+			t.Fatalf("expected one request per tag set, got %v", requests)
+		}
+		byPath := map[string][]string{}
+		for _, r := range requests {
+			byPath[r.path] = r.lines
+		}
+		if lines := byPath["/bulk/token/tag/some-api,develop/"]; len(lines) != 2 ||
+			!strings.Contains(lines[0], `"message":"first"`) || !strings.Contains(lines[1], `"message":"second"`) {
+			t.Errorf("unexpected lines for application tags: %v", byPath)
+		}
+		if lines := byPath["/bulk/token/tag/some-api,develop,requests/"]; len(lines) != 1 ||
+			!strings.Contains(lines[0], `"message":"third"`) {
+			t.Errorf("unexpected lines for sublogger tags: %v", byPath)
+		}
+	})
+
+	t.Run("Batch Size", func(t *testing.T) {
+		ls := newLogglyServer(t, 0)
+		defer ls.Close()
+
+		lw, err := NewLogglyWriter(LogglyConfig{
+			Token:         "token",
+			Endpoint:      ls.URL + "/bulk/",
+			BatchSize:     3,
+			FlushInterval: time.Hour,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer lw.Close()
+
+		for i := 0; i < 3; i++ {
+			_, _ = lw.Write([]byte("line\n"))
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for len(ls.received()) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("batch was not sent when it reached the batch size")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if r := ls.received()[0]; r.path != "/bulk/token/" || len(r.lines) != 3 {
+			t.Errorf("unexpected request: %v", r)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		ls := newLogglyServer(t, 2)
+		defer ls.Close()
+
+		lw, err := NewLogglyWriter(LogglyConfig{
+			Token:         "token",
+			Endpoint:      ls.URL + "/bulk/",
+			FlushInterval: time.Hour,
+			RetryBackoff:  time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer lw.Close()
+
+		_, _ = lw.Write([]byte("line\n"))
+		if err := lw.Flush(context.Background()); err != nil {
+			t.Fatalf("expected flush to succeed after retries, got %v", err)
+		}
+		if n := len(ls.received()); n != 1 {
+			t.Errorf("expected 1 successful request, got %d", n)
+		}
+		if n := lw.Dropped(); n != 0 {
+			t.Errorf("expected no dropped lines, got %d", n)
+		}
+	})
+
+	t.Run("Retries Exhausted", func(t *testing.T) {
+		ls := newLogglyServer(t, 10)
+		defer ls.Close()
+
+		lw, err := NewLogglyWriter(LogglyConfig{
+			Token:         "token",
+			Endpoint:      ls.URL + "/bulk/",
+			FlushInterval: time.Hour,
+			MaxRetries:    1,
+			RetryBackoff:  time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer lw.Close()
+
+		_, _ = lw.Write([]byte("line\n"))
+		if err := lw.Flush(context.Background()); err == nil {
+			t.Error("expected flush to fail")
+		}
+		if n := lw.Dropped(); n != 1 {
+			t.Errorf("expected 1 dropped line, got %d", n)
+		}
+	})
+
+	t.Run("Max Buffer", func(t *testing.T) {
+		ls := newLogglyServer(t, 0)
+		defer ls.Close()
+
+		lw, err := NewLogglyWriter(LogglyConfig{
+			Token:          "token",
+			Endpoint:       ls.URL + "/bulk/",
+			FlushInterval:  time.Hour,
+			MaxBufferBytes: 10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 3; i++ {
+			_, _ = lw.Write([]byte("line\n"))
+		}
+		_ = lw.Close()
+
+		if n := lw.Dropped(); n != 1 {
+			t.Errorf("expected 1 dropped line, got %d", n)
+		}
+		if r := ls.received(); len(r) != 1 || len(r[0].lines) != 2 {
+			t.Errorf("expected 2 lines to be sent, got %v", r)
+		}
+	})
+}
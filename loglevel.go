@@ -49,6 +49,40 @@ func (l Level) color() chalk.Color {
 	}
 }
 
+// syslogSeverity returns the syslog severity code for the level
+func (l Level) syslogSeverity() int {
+	switch l {
+	case LogLevelFatal:
+		return 2 // critical
+	case LogLevelError:
+		return 3 // error
+	case LogLevelWarn:
+		return 4 // warning
+	case LogLevelInfo:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
+
+// googleCloudSeverity returns the Google Cloud Logging severity for the level
+func (l Level) googleCloudSeverity() string {
+	switch l {
+	case LogLevelTrace, LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARNING"
+	case LogLevelError:
+		return "ERROR"
+	case LogLevelFatal:
+		return "CRITICAL"
+	default:
+		return "DEFAULT"
+	}
+}
+
 // MARK: String interface methods
 
 func (l Level) String() string {
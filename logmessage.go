@@ -1,9 +1,11 @@
 package log
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -19,10 +21,16 @@ type logMessage struct {
 	Metadata  interface{} `json:"metadata,omitempty"`
 	File      string      `json:"file,omitempty"`
 
+	fields       []Field
+	callFields   []Field
 	format       Format
+	options      formatOptions
+	time         time.Time
+	timeFormat   TimeFormat
 	rawLevel     Level
 	colorize     bool
 	logCaller    bool
+	pc           uintptr
 	trimmedLeft  string
 	trimmedRight string
 }
@@ -45,26 +53,22 @@ func newLogMessage(
 	modifiedMessage := strings.TrimSpace(message)
 
 	caller := ""
+	var pc uintptr
 	if logCaller {
 		var file string
 		var line int
 		var ok bool
-		_, file, line, ok = runtime.Caller(callerSkip + 1)
-		if ok {
-			fileComponents := strings.Split(file, "/")
-			if len(fileComponents) > 1 {
-				file = fileComponents[len(fileComponents)-1]
-			}
-		} else {
-			file = "???"
-			line = 0
-		}
-		caller = fmt.Sprintf("%s:%d", file, line)
+		pc, file, line, ok = runtime.Caller(callerSkip + 1)
+		caller = callerFile(file, line, ok)
 	}
 
 	// Make sure that error interface types in logMessage.Metadata are not
 	// marshalled as empty JSON objects
 	metadata := data
+	fields, isFields := callFields(data)
+	if isFields {
+		metadata = nil
+	}
 	if e, ok := data.(error); ok {
 		if _, ok := e.(json.Marshaler); !ok {
 			metadata = e.Error()
@@ -82,24 +86,18 @@ func newLogMessage(
 		}
 	}
 
-	var timestamp string
-	if timeFormat == TimeFormatLoggly {
-		// According to loggly documentation:
-		// * The only timestamp format accepted is ISO 8601 (e.g., 2013-10-11T22:14:15.003Z).
-		// * Loggly supports microseconds/seconds fraction up to 6 digits, per the spec in RFC5424.
-		timestamp = t.UTC().Format("2006-01-02T15:04:05.000000Z07:00")
-	} else {
-		timestamp = t.String()
-	}
-
 	formattedMessage := &logMessage{
-		Timestamp:    timestamp,
+		Timestamp:    timeFormat.format(t),
 		Level:        level.String(),
 		Tags:         tags,
 		Message:      modifiedMessage,
 		Metadata:     metadata,
 		File:         caller,
+		pc:           pc,
+		callFields:   fields,
 		format:       format,
+		time:         t,
+		timeFormat:   timeFormat,
 		rawLevel:     level,
 		colorize:     colorize,
 		logCaller:    logCaller,
@@ -110,99 +108,211 @@ func newLogMessage(
 	return formattedMessage
 }
 
-// MARK: Methods
-
-func (m logMessage) restoreWhitespace(output string) string {
-	return m.trimmedLeft + output + m.trimmedRight
+// callerFile returns the "file:line" a message was logged from, with the
+// file's directory removed
+func callerFile(file string, line int, ok bool) string {
+	if !ok {
+		return "???:0"
+	}
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		file = file[i+1:]
+	}
+	return file + ":" + strconv.Itoa(line)
 }
 
-func (m logMessage) colorizeIfNeeded(output string) string {
-	if !m.colorize {
-		return output
+// MARK: Methods
+
+// colorizeAppended colorizes each line of dst that was appended after start
+func (m logMessage) colorizeAppended(dst []byte, start int) []byte {
+	color, reset := m.rawLevel.color().String(), chalk.ResetColor.String()
+	if bytes.IndexByte(dst[start:], '\n') < 0 {
+		n := len(dst)
+		dst = append(dst, color...)
+		copy(dst[start+len(color):], dst[start:n])
+		copy(dst[start:], color)
+		return append(dst, reset...)
 	}
 
-	lines := strings.Split(output, "\n")
-	for i, line := range lines {
-		lines[i] = fmt.Sprintf("%s%s%s", m.rawLevel.color(), line, chalk.ResetColor)
+	scratch := getBuffer()
+	defer putBuffer(scratch)
+	*scratch = append(*scratch, dst[start:]...)
+	dst = dst[:start]
+	for i, line := range bytes.Split(*scratch, []byte("\n")) {
+		if i > 0 {
+			dst = append(dst, '\n')
+		}
+		dst = append(dst, color...)
+		dst = append(dst, line...)
+		dst = append(dst, reset...)
 	}
+	return dst
+}
 
-	return strings.Join(lines, "\n")
+// MarshalJSON marshals the message, writing numeric timestamps as JSON numbers
+// and adding fields as top-level keys
+func (m logMessage) MarshalJSON() ([]byte, error) {
+	return m.appendJSON(nil), nil
 }
 
-func (m logMessage) jsonString() string {
-	s, _ := json.Marshal(m)
-	return m.colorizeIfNeeded(string(s))
+// appendJSON appends the message as JSON with its default keys
+func (m logMessage) appendJSON(dst []byte) []byte {
+	dst = append(dst, '{')
+	dst = appendJSONKey(dst, "timestamp")
+	if m.timeFormat.numeric() {
+		dst = append(dst, m.Timestamp...)
+	} else {
+		dst = appendJSONString(dst, m.Timestamp)
+	}
+	dst = appendJSONKey(dst, "level")
+	dst = appendJSONString(dst, m.Level)
+	dst = appendJSONKey(dst, "tags")
+	dst = appendJSONStrings(dst, m.Tags)
+	dst = appendJSONKey(dst, "message")
+	dst = appendJSONString(dst, m.Message)
+	if m.Metadata != nil {
+		dst = appendJSONKey(dst, "metadata")
+		dst = appendJSONValue(dst, m.Metadata)
+	}
+	if m.File != "" {
+		dst = appendJSONKey(dst, "file")
+		dst = appendJSONString(dst, m.File)
+	}
+	dst = appendJSONFields(dst, m.allFields(), isMessageKey)
+	return append(dst, '}')
 }
 
-// MARK: String interface methods
+// render returns the message in the given format, colorized if requested
+func (m logMessage) render(format Format, colorize bool) string {
+	return string(m.appendRender(nil, format, colorize))
+}
 
-func (m logMessage) String() string {
-	if m.format == LogFormatJSON {
-		return m.jsonString()
+// appendRender appends the message in the given format, colorized if
+// requested
+func (m logMessage) appendRender(dst []byte, format Format, colorize bool) []byte {
+	start := len(dst)
+	switch format {
+	case LogFormatJSON:
+		if m.options.jsonLayout != nil {
+			dst = m.appendJSONLayout(dst, m.options.jsonLayout)
+		} else {
+			dst = m.appendJSON(dst)
+		}
+	case LogFormatLogfmt:
+		dst = m.appendLogfmt(dst)
+	case LogFormatGoogleCloud:
+		dst = m.appendGoogleCloud(dst)
+	case LogFormatECS:
+		dst = m.appendECS(dst)
+	case LogFormatPretty:
+		dst = m.appendPretty(dst)
+	default:
+		if f, ok := registeredFormatter(format); ok {
+			dst = append(dst, f.Format(m.entry())...)
+		} else {
+			dst = m.appendPretty(dst)
+		}
 	}
 
-	var timeAndLevel, logCaller, tags, data string
+	if colorize {
+		dst = m.colorizeAppended(dst, start)
+	}
+	return dst
+}
+
+// appendPretty appends the message in the pretty layout, or in the logger's
+// pretty template if it has one
+func (m logMessage) appendPretty(dst []byte) []byte {
+	if m.options.prettyTemplate != nil {
+		if s, err := m.templateString(m.options.prettyTemplate); err == nil {
+			return append(dst, s...)
+		}
+	}
 
-	timeAndLevel = fmt.Sprintf("%s [%s] ", m.Timestamp, m.Level)
+	dst = append(dst, m.Timestamp...)
+	dst = append(dst, " ["...)
+	dst = append(dst, m.Level...)
+	dst = append(dst, "] "...)
 
 	if m.logCaller {
-		logCaller = fmt.Sprintf("[%s] ", m.File)
+		dst = append(dst, '[')
+		dst = append(dst, m.File...)
+		dst = append(dst, "] "...)
 	}
 
 	if len(m.Tags) > 0 {
-		tags = fmt.Sprintf("(%s) ", strings.Join(m.Tags, ","))
+		dst = append(dst, '(')
+		for i, tag := range m.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, tag...)
+		}
+		dst = append(dst, ") "...)
 	}
 
-	if m.Metadata != nil {
-		switch m.Metadata.(type) {
-		case string:
-			data = m.Metadata.(string)
-		case fmt.Stringer:
-			data = m.Metadata.(fmt.Stringer).String()
-		case error:
-			data = m.Metadata.(error).Error()
-		default:
-			data = fmt.Sprintf("%#v", m.Metadata)
-		}
+	dst = append(dst, m.trimmedLeft...)
+	dst = append(dst, m.Message...)
+	dst = append(dst, ' ')
+	if fields := m.allFields(); len(fields) > 0 {
+		dst = appendPrettyFields(dst, fields)
+		dst = append(dst, ' ')
 	}
+	dst = append(dst, m.prettyData()...)
+	return append(dst, m.trimmedRight...)
+}
 
-	prettyMessage := timeAndLevel + logCaller + tags +
-		m.trimmedLeft + m.Message + " " + data + m.trimmedRight
+// prettyData returns the metadata as it is shown in the pretty layout
+func (m logMessage) prettyData() string {
+	if m.Metadata == nil {
+		return ""
+	}
 
-	return m.colorizeIfNeeded(prettyMessage)
+	switch m.Metadata.(type) {
+	case string:
+		return m.Metadata.(string)
+	case fmt.Stringer:
+		return m.Metadata.(fmt.Stringer).String()
+	case error:
+		return m.Metadata.(error).Error()
+	default:
+		return fmt.Sprintf("%#v", m.Metadata)
+	}
+}
+
+// MARK: String interface methods
+
+func (m logMessage) String() string {
+	return m.render(m.format, m.colorize)
 }
 
 // MARK: Helper Functions
 
+// isMessageKey reports whether a key is one of the default JSON keys of a
+// logMessage
+func isMessageKey(key string) bool {
+	switch key {
+	case "timestamp", "level", "tags", "message", "metadata", "file":
+		return true
+	}
+	return false
+}
+
 // return the leading whitespace of the input string
 func leadingWhitespace(s string) string {
-	var b strings.Builder
-	for i, _ := range s {
+	for i := 0; i < len(s); i++ {
 		if !unicode.IsSpace(rune(s[i])) {
-			return b.String()
+			return s[:i]
 		}
-		b.Write([]byte{s[i]})
 	}
-	return b.String()
+	return s
 }
 
 // return the trailing whitespace of the input string
 func trailingWhitespace(s string) string {
-	var rev strings.Builder
 	for i := len(s); i > 0; i-- {
 		if !unicode.IsSpace(rune(s[i-1])) {
-			return reverseString(rev.String())
+			return s[i:]
 		}
-		rev.Write([]byte{s[i-1]})
-	}
-	return rev.String()
-}
-
-// reverse a string
-func reverseString(s string) string {
-	r := []rune(s)
-	for i, j := 0, 0; i < j; i, j = i+1, j-1 {
-		r[i], r[j] = r[j], r[i]
 	}
-	return string(r)
+	return s
 }
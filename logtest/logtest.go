@@ -0,0 +1,179 @@
+// Package logtest provides a Logger that records entries in memory, for
+// asserting on what code logs in tests without capturing its output.
+package logtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/parkhub/go-parkhub-logger"
+)
+
+// MARK: Types
+
+// Recorder is a log.Logger that records the entries it logs. It logs every
+// level and the caller, and messages at the fatal level are recorded without
+// exiting. It is safe for concurrent use.
+type Recorder struct {
+	log.Logger
+
+	mu      sync.Mutex
+	entries Entries
+	t       testing.TB
+	done    bool
+}
+
+// Entries are recorded entries, in the order they were logged.
+type Entries []log.Entry
+
+// MARK: Public Functions
+
+// New returns a Recorder
+func New() *Recorder {
+	return NewWithConfig(log.LoggerConfig{Level: log.LogLevelTrace, LogCaller: true})
+}
+
+// NewWithConfig returns a Recorder with the level, time format and location,
+// tags and whether to log the caller read from the config
+func NewWithConfig(config log.LoggerConfig) *Recorder {
+	r := &Recorder{}
+	r.Logger = log.NewEntryLogger(log.EntryWriterFunc(r.record), config)
+	return r
+}
+
+// NewT returns a Recorder that also writes each entry to the test's log with
+// t.Logf, until the test and its subtests complete
+func NewT(t testing.TB) *Recorder {
+	r := New()
+	r.t = t
+	t.Cleanup(func() {
+		r.mu.Lock()
+		r.done = true
+		r.mu.Unlock()
+	})
+	return r
+}
+
+// MARK: Recorder Methods
+
+// Entries returns a copy of the recorded entries
+func (r *Recorder) Entries() Entries {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(Entries(nil), r.entries...)
+}
+
+// Len returns the number of recorded entries
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// Reset removes the recorded entries
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// FilterLevel returns the recorded entries logged at the level
+func (r *Recorder) FilterLevel(level log.Level) Entries {
+	return r.Entries().FilterLevel(level)
+}
+
+// FilterTag returns the recorded entries that have the tag
+func (r *Recorder) FilterTag(tag string) Entries {
+	return r.Entries().FilterTag(tag)
+}
+
+// FilterMessageContains returns the recorded entries whose message contains
+// the substring
+func (r *Recorder) FilterMessageContains(substr string) Entries {
+	return r.Entries().FilterMessageContains(substr)
+}
+
+// MARK: Entries Methods
+
+// FilterLevel returns the entries logged at the level
+func (es Entries) FilterLevel(level log.Level) Entries {
+	return es.filter(func(e log.Entry) bool {
+		return e.Level == level
+	})
+}
+
+// FilterTag returns the entries that have the tag
+func (es Entries) FilterTag(tag string) Entries {
+	return es.filter(func(e log.Entry) bool {
+		for _, t := range e.Tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FilterMessageContains returns the entries whose message contains the
+// substring
+func (es Entries) FilterMessageContains(substr string) Entries {
+	return es.filter(func(e log.Entry) bool {
+		return strings.Contains(e.Message, substr)
+	})
+}
+
+// Messages returns the messages of the entries
+func (es Entries) Messages() []string {
+	messages := make([]string, len(es))
+	for i, e := range es {
+		messages[i] = e.Message
+	}
+	return messages
+}
+
+// MARK: Private Methods
+
+// record records the entry, and writes it to the test's log if there is one
+func (r *Recorder) record(e log.Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+	if r.t != nil && !r.done {
+		r.t.Logf("%s", format(e))
+	}
+}
+
+// filter returns the entries that match
+func (es Entries) filter(match func(e log.Entry) bool) Entries {
+	var filtered Entries
+	for _, e := range es {
+		if match(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// MARK: Private Functions
+
+// format returns the entry as a line for a test's log
+func format(e log.Entry) string {
+	var b strings.Builder
+	b.WriteString("[" + e.Level.String() + "]")
+	if e.File != "" {
+		b.WriteString(" [" + e.File + "]")
+	}
+	if len(e.Tags) > 0 {
+		b.WriteString(" (" + strings.Join(e.Tags, ",") + ")")
+	}
+	b.WriteString(" " + e.Message)
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	if e.Metadata != nil {
+		fmt.Fprintf(&b, " %+v", e.Metadata)
+	}
+	return b.String()
+}
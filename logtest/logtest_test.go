@@ -0,0 +1,114 @@
+package logtest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/parkhub/go-parkhub-logger"
+)
+
+func TestRecorder(t *testing.T) {
+	r := New()
+	r.Infoln("Lot opened.")
+	r.Sublogger("lots").Warnd("Lot full.", errors.New("no spaces"))
+	r.With(log.Int("lot", 7)).Errorw("Gate stuck.", "gate", 2)
+	r.Info().Str("requestId", "abc").Msg("Lot closed.")
+	r.Fatalln("Lot on fire.")
+
+	entries := r.Entries()
+	if got := entries.Messages(); !reflect.DeepEqual(got, []string{
+		"Lot opened.", "Lot full.", "Gate stuck.", "Lot closed.", "Lot on fire.",
+	}) {
+		t.Fatalf("unexpected messages %q", got)
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.File, "logtest_test.go:") {
+			t.Errorf("expected the caller of %q, got %q", e.Message, e.File)
+		}
+	}
+
+	if e := entries[1]; e.Level != log.LogLevelWarn || !reflect.DeepEqual(e.Tags, []string{"lots"}) || e.Metadata != "no spaces" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e := entries[2]; !reflect.DeepEqual(e.Fields, []log.Field{log.Int("lot", 7), {Key: "gate", Value: 2}}) {
+		t.Errorf("unexpected fields %+v", e.Fields)
+	}
+	// event fields are reused after the event is written
+	r.Info().Str("requestId", "def").Msg("Lot opened.")
+	if e := r.Entries()[3]; !reflect.DeepEqual(e.Fields, []log.Field{log.String("requestId", "abc")}) {
+		t.Errorf("unexpected fields %+v", e.Fields)
+	}
+
+	if r.Len() != 6 {
+		t.Errorf("expected 6 entries, got %d", r.Len())
+	}
+	r.Reset()
+	if r.Len() != 0 {
+		t.Errorf("expected no entries, got %d", r.Len())
+	}
+}
+
+func TestFilters(t *testing.T) {
+	r := New()
+	r.Infoln("Lot opened.")
+	r.Sublogger("lots").Infoln("Lot full.")
+	r.Sublogger("gates").Errorln("Gate stuck.")
+	r.Sublogger("lots", "gates").Errorln("Lot gate stuck.")
+
+	tests := []struct {
+		name string
+		got  Entries
+		want []string
+	}{
+		{"Level", r.FilterLevel(log.LogLevelError), []string{"Gate stuck.", "Lot gate stuck."}},
+		{"Tag", r.FilterTag("lots"), []string{"Lot full.", "Lot gate stuck."}},
+		{"Message", r.FilterMessageContains("stuck"), []string{"Gate stuck.", "Lot gate stuck."}},
+		{"Chained", r.FilterTag("lots").FilterLevel(log.LogLevelInfo), []string{"Lot full."}},
+		{"None", r.FilterMessageContains("closed"), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Messages(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	r := NewWithConfig(log.LoggerConfig{Level: log.LogLevelWarn, Tags: []string{"api"}})
+	r.Infoln("dropped")
+	r.Warnln("Lot full.")
+
+	entries := r.Entries()
+	if len(entries) != 1 || entries[0].File != "" || !reflect.DeepEqual(entries[0].Tags, []string{"api"}) {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
+
+// testingT records what is logged to a test's log
+type testingT struct {
+	testing.TB
+	mu    sync.Mutex
+	lines []string
+}
+
+func (t *testingT) Logf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, strings.TrimSpace(strings.Replace(format, "%s", args[0].(string), 1)))
+}
+
+func TestNewT(t *testing.T) {
+	tt := &testingT{TB: t}
+	r := NewT(tt)
+	r.Sublogger("lots").With(log.Int("lot", 7)).Warnd("Lot full.", map[string]int{"spaces": 0})
+
+	if len(tt.lines) != 1 || !strings.HasPrefix(tt.lines[0], "[WARN] [logtest_test.go:") ||
+		!strings.HasSuffix(tt.lines[0], "(lots) Lot full. lot=7 map[spaces:0]") {
+		t.Errorf("unexpected lines %q", tt.lines)
+	}
+}
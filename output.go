@@ -0,0 +1,123 @@
+package log
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// MARK: Types
+
+// Flusher is implemented by outputs that buffer log lines. Flush should return
+// once every line written before the call has been delivered, or when the
+// context is done.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// LevelWriter is implemented by outputs that need the level and tags of each
+// log line, such as to set its severity when shipping it. The logger calls
+// WriteLevel instead of Write for outputs that implement it.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(level Level, tags []string, p []byte) (int, error)
+}
+
+// syncWriter serializes writes to an io.Writer so that log lines written from
+// concurrent goroutines are never interleaved
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// stdout is shared by every logger without an explicit output so that their
+// lines are serialized with each other
+var stdout = newSyncWriter(nil)
+
+// MARK: Private Functions
+
+// newSyncWriter returns a syncWriter for w. A nil writer writes to whatever
+// os.Stdout is at the time of the write.
+func newSyncWriter(w io.Writer) *syncWriter {
+	return &syncWriter{w: w}
+}
+
+// writeLevel writes p to w with its level and tags if w is a LevelWriter
+func writeLevel(w io.Writer, level Level, tags []string, p []byte) (int, error) {
+	if lw, ok := w.(LevelWriter); ok {
+		return lw.WriteLevel(level, tags, p)
+	}
+	return w.Write(p)
+}
+
+// flushOutput flushes w if it buffers log lines
+func flushOutput(ctx context.Context, w io.Writer) error {
+	switch f := w.(type) {
+	case Flusher:
+		return f.Flush(ctx)
+	case interface{ Flush() error }:
+		return f.Flush()
+	default:
+		return nil
+	}
+}
+
+// closeOutput closes w if it can be closed. The process's standard output and
+// error are never closed.
+func closeOutput(ctx context.Context, w io.Writer) error {
+	switch c := w.(type) {
+	case *syncWriter:
+		if c.w == nil {
+			return nil
+		}
+		return closeOutput(ctx, c.w)
+	case *asyncWriter:
+		if err := c.close(ctx); err != nil {
+			return err
+		}
+		return closeOutput(ctx, c.w)
+	case *os.File:
+		if c == os.Stdout || c == os.Stderr {
+			return nil
+		}
+		return c.Close()
+	case io.Closer:
+		return c.Close()
+	default:
+		return nil
+	}
+}
+
+// MARK: Methods
+
+// Write writes p to the underlying writer while holding the writer's lock
+func (sw *syncWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.w == nil {
+		return os.Stdout.Write(p)
+	}
+	return sw.w.Write(p)
+}
+
+// WriteLevel writes p with its level and tags to the underlying writer while
+// holding the writer's lock
+func (sw *syncWriter) WriteLevel(level Level, tags []string, p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.w == nil {
+		return os.Stdout.Write(p)
+	}
+	return writeLevel(sw.w, level, tags, p)
+}
+
+// Flush flushes the underlying writer while holding the writer's lock
+func (sw *syncWriter) Flush(ctx context.Context) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.w == nil {
+		return nil
+	}
+	return flushOutput(ctx, sw.w)
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOutput(t *testing.T) {
+	t.Run("Writes To Output", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			Format:     LogFormatPretty,
+			TimeFormat: TimeFormatLoggly,
+			Tags:       []string{"test"},
+			Output:     &buf,
+		})
+
+		l.Infoln("This is an info statement.")
+		l.Sublogger("sub").Debugln("This is a debug statement.")
+		l.Traceln("This is filtered out.")
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+		}
+		if !strings.Contains(lines[0], "[INFO] (test) This is an info statement.") {
+			t.Errorf("unexpected info line: %q", lines[0])
+		}
+		if !strings.Contains(lines[1], "[DEBUG] (test,sub) This is a debug statement.") {
+			t.Errorf("unexpected debug line: %q", lines[1])
+		}
+	})
+
+	t.Run("Concurrent Writes", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := NewLogger(LoggerConfig{
+			Level:      LogLevelDebug,
+			Format:     LogFormatJSON,
+			TimeFormat: TimeFormatLoggly,
+			Output:     &buf,
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					l.Infod("concurrent", map[string]int{"goroutine": i, "line": j})
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		var count int
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var m map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				t.Fatalf("interleaved line %q: %v", scanner.Text(), err)
+			}
+			count++
+		}
+		if count != 1000 {
+			t.Errorf("expected 1000 lines, got %d", count)
+		}
+	})
+
+	t.Run("Setup With Config", func(t *testing.T) {
+		var buf bytes.Buffer
+		SetupLoggerWithConfig(LoggerConfig{
+			Level:      LogLevelInfo,
+			Format:     LogFormatJSON,
+			TimeFormat: TimeFormatLoggly,
+			Output:     &buf,
+		})
+		defer SetupLocalLogger(LogLevelDebug)
+
+		Infoln("This is an info statement.")
+		if !strings.Contains(buf.String(), `"message":"This is an info statement."`) {
+			t.Errorf("expected log in output, got %q", buf.String())
+		}
+	})
+}
+
+func TestSinks(t *testing.T) {
+	var console, file bytes.Buffer
+	l := NewLogger(LoggerConfig{
+		TimeFormat: TimeFormatLoggly,
+		Tags:       []string{"test"},
+		Sinks: []Sink{
+			{Output: &console, Level: LogLevelDebug, Format: LogFormatPretty, Colorize: true},
+			{Output: &file, Level: LogLevelInfo, Format: LogFormatJSON},
+		},
+	})
+
+	l.Traceln("This is filtered out.")
+	l.Debugln("This is a debug statement.")
+	l.Infoln("This is an info statement.")
+
+	consoleLines := strings.Split(strings.TrimSuffix(console.String(), "\n"), "\n")
+	if len(consoleLines) != 2 {
+		t.Fatalf("expected 2 console lines, got %d: %q", len(consoleLines), console.String())
+	}
+	if !strings.HasPrefix(consoleLines[0], LogLevelDebug.color().String()) ||
+		!strings.Contains(consoleLines[0], "[DEBUG] (test) This is a debug statement.") {
+		t.Errorf("unexpected console line: %q", consoleLines[0])
+	}
+
+	fileLines := strings.Split(strings.TrimSuffix(file.String(), "\n"), "\n")
+	if len(fileLines) != 1 {
+		t.Fatalf("expected 1 file line, got %d: %q", len(fileLines), file.String())
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(fileLines[0]), &m); err != nil {
+		t.Fatalf("file line is not JSON: %q", fileLines[0])
+	}
+	if m["level"] != "INFO" || m["message"] != "This is an info statement." {
+		t.Errorf("unexpected file line: %q", fileLines[0])
+	}
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// processStart is the time that relative timestamps are measured from
+var processStart = time.Now()
+
+// prettyFuncs are the helper functions available to pretty templates
+var prettyFuncs = template.FuncMap{
+	"pad":        prettyPad,
+	"padLeft":    prettyPadLeft,
+	"truncate":   prettyTruncate,
+	"shortLevel": prettyShortLevel,
+	"relative":   prettyRelative,
+	"join":       prettyJoin,
+	"upper":      prettyUpper,
+	"lower":      prettyLower,
+}
+
+// MARK: Types
+
+// prettyEntry is the data a pretty template is executed with. Besides the
+// Entry fields, Data is the metadata as shown in the default pretty layout.
+type prettyEntry struct {
+	Entry
+	Data string
+}
+
+// MARK: Methods
+
+// templateString returns the message rendered with a pretty template
+func (m logMessage) templateString(t *template.Template) (string, error) {
+	var b strings.Builder
+	err := t.Execute(&b, prettyEntry{
+		Entry: m.entry(),
+		Data:  m.prettyData(),
+	})
+	return b.String(), err
+}
+
+// MARK: Private Functions
+
+// parsePrettyTemplate parses a pretty template layout with the helper
+// functions available
+func parsePrettyTemplate(layout string) (*template.Template, error) {
+	return template.New("pretty").Funcs(prettyFuncs).Parse(layout)
+}
+
+// prettyPad pads v with spaces on the right to at least width runes
+func prettyPad(width int, v interface{}) string {
+	s := fmt.Sprint(v)
+	if n := utf8.RuneCountInString(s); n < width {
+		s += strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// prettyPadLeft pads v with spaces on the left to at least width runes
+func prettyPadLeft(width int, v interface{}) string {
+	s := fmt.Sprint(v)
+	if n := utf8.RuneCountInString(s); n < width {
+		s = strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
+
+// prettyTruncate shortens v to at most width runes, ending it with an ellipsis
+// if it was cut
+func prettyTruncate(width int, v interface{}) string {
+	s := fmt.Sprint(v)
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:width-1]) + "…"
+}
+
+// prettyJoin joins elems with sep, so that tags can be joined with
+// {{join "," .Tags}}
+func prettyJoin(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+// prettyUpper returns v in upper case
+func prettyUpper(v interface{}) string {
+	return strings.ToUpper(fmt.Sprint(v))
+}
+
+// prettyLower returns v in lower case
+func prettyLower(v interface{}) string {
+	return strings.ToLower(fmt.Sprint(v))
+}
+
+// prettyShortLevel returns a three letter abbreviation of a level
+func prettyShortLevel(level Level) string {
+	switch level {
+	case LogLevelTrace:
+		return "TRC"
+	case LogLevelDebug:
+		return "DBG"
+	case LogLevelInfo:
+		return "INF"
+	case LogLevelWarn:
+		return "WRN"
+	case LogLevelError:
+		return "ERR"
+	case LogLevelFatal:
+		return "FTL"
+	}
+	return "???"
+}
+
+// prettyRelative returns how long after the process started t is, rounded to
+// the millisecond
+func prettyRelative(t time.Time) string {
+	return t.Sub(processStart).Round(time.Millisecond).String()
+}
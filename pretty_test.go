@@ -0,0 +1,83 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPrettyTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "Fields",
+			template: `{{.Level}} {{join "," .Tags}} {{.File}} {{.Message}} {{.Data}}`,
+			want:     `^INFO a,b pretty_test.go:\d+ hello 42$`,
+		},
+		{
+			name:     "Compact",
+			template: `{{shortLevel .Level}} {{.Time.Format "15:04:05"}} {{.Message}}`,
+			want:     `^INF \d\d:\d\d:\d\d hello$`,
+		},
+		{
+			name:     "Padding",
+			template: `[{{pad 6 .Level}}][{{padLeft 6 .Level}}] {{.Message}}`,
+			want:     `^\[INFO  \]\[  INFO\] hello$`,
+		},
+		{
+			name:     "Truncate",
+			template: `{{truncate 3 .Message}}|{{truncate 10 .Message}}|{{lower .Level}}`,
+			want:     `^he…\|hello\|info$`,
+		},
+		{
+			name:     "Relative",
+			template: `+{{relative .Time}} {{.Message}}`,
+			want:     `^\+\d[\dhms.]* hello$`,
+		},
+		{
+			name:     "Invalid",
+			template: `{{.Message`,
+			want:     `\[INFO\] .*hello 42$`,
+		},
+		{
+			name:     "Execution Error",
+			template: `{{.Missing}}`,
+			want:     `\[INFO\] .*hello 42$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf syncBuffer
+			l := NewLogger(LoggerConfig{
+				Level:          LogLevelDebug,
+				Format:         LogFormatPretty,
+				LogCaller:      true,
+				Tags:           []string{"a", "b"},
+				Output:         &buf,
+				PrettyTemplate: tt.template,
+			})
+			l.Infod("hello", 42)
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if got := lines[len(lines)-1]; !regexp.MustCompile(tt.want).MatchString(got) {
+				t.Errorf("got %q, want match for %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Invalid Logged", func(t *testing.T) {
+		var buf syncBuffer
+		NewLogger(LoggerConfig{
+			Level:          LogLevelDebug,
+			Output:         &buf,
+			PrettyTemplate: `{{.Message`,
+		})
+		if !strings.Contains(buf.String(), "[ERROR] invalid pretty template") {
+			t.Errorf("expected template error to be logged, got %q", buf.String())
+		}
+	})
+}
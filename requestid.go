@@ -0,0 +1,72 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// DefaultRequestIDHeader is the header RequestLogger reads, echoes and
+// forwards request IDs in unless another is configured
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the key of the field request IDs are logged under
+const RequestIDKey = "requestId"
+
+// maxRequestIDLength is the longest incoming request ID that is accepted
+const maxRequestIDLength = 128
+
+// MARK: Public Functions
+
+// ContextWithRequestID returns a copy of ctx that carries the request ID.
+// RoundTrip forwards it on outbound requests made with the context.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(requestIDContextKey).(string)
+	return id, ok && id != ""
+}
+
+// MARK: Private Functions
+
+// newRequestID returns a random version 4 UUID
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("log: reading random request ID: " + err.Error())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	id := make([]byte, 36)
+	hex.Encode(id[0:8], b[0:4])
+	id[8] = '-'
+	hex.Encode(id[9:13], b[4:6])
+	id[13] = '-'
+	hex.Encode(id[14:18], b[6:8])
+	id[18] = '-'
+	hex.Encode(id[19:23], b[8:10])
+	id[23] = '-'
+	hex.Encode(id[24:], b[10:])
+	return string(id)
+}
+
+// validRequestID reports whether an incoming request ID is safe to log and
+// echo: not empty, not too long, and only printable ASCII
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNewRequestID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := newRequestID(), newRequestID()
+	if !uuid.MatchString(a) || !uuid.MatchString(b) || a == b {
+		t.Errorf("expected unique version 4 UUIDs, got %q and %q", a, b)
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := map[string]bool{
+		"abc-123":                  true,
+		"":                         false,
+		"has space":                false,
+		"line\nbreak":              false,
+		strings.Repeat("a", 128):   true,
+		strings.Repeat("a", 129):   false,
+		"01HF8X5Q3J9ZB7KQ2W4N6R8T": true,
+	}
+	for id, want := range tests {
+		if got := validRequestID(id); got != want {
+			t.Errorf("validRequestID(%q) = %t, want %t", id, got, want)
+		}
+	}
+}
+
+func TestRequestLogger_RequestID(t *testing.T) {
+	var upstream string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream = r.Header.Get("X-Correlation-ID")
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	rl := NewRequestLogger(RequestLoggerConfig{
+		Logger:          NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf}),
+		Client:          server.Client(),
+		RequestIDHeader: "x-correlation-id",
+	})
+
+	var handlerID string
+	h := rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerID, _ = RequestIDFromContext(r.Context())
+		FromContext(r.Context()).Infoln("handling")
+
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, server.URL, nil)
+		if _, err := rl.RoundTripper(nil).RoundTrip(req); err != nil {
+			t.Errorf("RoundTrip() error = %v", err)
+		}
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/lots", nil)
+	r.Header.Set("X-Correlation-ID", "abc-123")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if handlerID != "abc-123" || upstream != "abc-123" {
+		t.Errorf("expected the incoming ID in the context and upstream, got %q and %q", handlerID, upstream)
+	}
+	if got := w.Header().Get("X-Correlation-ID"); got != "abc-123" {
+		t.Errorf("expected the ID echoed on the response, got %q", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected handler, outbound and request logs, got %q", lines)
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		if entry[RequestIDKey] != "abc-123" {
+			t.Errorf("expected the request ID in %q", line)
+		}
+	}
+
+	t.Run("Generated", func(t *testing.T) {
+		for _, incoming := range []string{"", "not valid"} {
+			r := httptest.NewRequest(http.MethodGet, "/lots", nil)
+			r.Header.Set("X-Correlation-ID", incoming)
+			w := httptest.NewRecorder()
+			rl.Handle(http.NotFoundHandler()).ServeHTTP(w, r)
+
+			if got := w.Header().Get("X-Correlation-ID"); !validRequestID(got) || got == incoming {
+				t.Errorf("expected a generated ID for %q, got %q", incoming, got)
+			}
+		}
+	})
+
+	t.Run("Default Header", func(t *testing.T) {
+		rl := NewRequestLogger(RequestLoggerConfig{
+			Logger: NewLogger(LoggerConfig{Output: &syncBuffer{}}),
+		})
+		w := httptest.NewRecorder()
+		rl.Handle(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/lots", nil))
+
+		if w.Header().Get(DefaultRequestIDHeader) == "" {
+			t.Errorf("expected an ID in %s", DefaultRequestIDHeader)
+		}
+	})
+}
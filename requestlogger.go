@@ -1,12 +1,14 @@
 package log
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -19,6 +21,7 @@ import (
 type RequestLogger struct {
 	client                *http.Client
 	logger                Logger
+	contextLogger         Logger
 	logHeaders            bool
 	logParams             bool
 	logBody               bool
@@ -27,6 +30,7 @@ type RequestLogger struct {
 	deadlineExceededLevel Level
 	contextCancelledLevel Level
 	contextErrorLevel     Level
+	requestIDHeader       string
 }
 
 // RequestLoggerConfig defines options for which details should be logged
@@ -52,6 +56,16 @@ type RequestLoggerConfig struct {
 	// ContextErrorLevel is the log level to use for requests that have an other
 	// context error
 	ContextErrorLevel Level
+
+	// RequestIDHeader is the header request IDs are read from, echoed in and
+	// forwarded in. It defaults to DefaultRequestIDHeader.
+	RequestIDHeader string
+}
+
+// statusRecorder records the status code written to a ResponseWriter
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
 }
 
 // requestLog stores the request data for logging
@@ -59,6 +73,11 @@ type requestLog struct {
 	headers      map[string][]string
 	method       string
 	path         string
+	url          string
+	protocol     string
+	userAgent    string
+	remoteIP     string
+	status       int
 	params       map[string][]string
 	body         string
 	graphql      string
@@ -69,7 +88,19 @@ type requestLog struct {
 // MARK: Public Methods
 
 // Handle logs incoming HTTP requests, calls the next handler, and logs uncaught
-// errors in the handler chain
+// errors in the handler chain. The request's context carries a logger with the
+// RequestLogger's tags, which handlers can get with FromContext or log with
+// using the *Ctx functions.
+//
+// Each request is handled in a span of the trace in its traceparent header, or
+// of a new trace if it has none. The trace and span IDs are logged with the
+// request log and by the context's logger, and the context carries the span's
+// TraceParent for RoundTrip to propagate.
+//
+// Each request is also identified by the ID in its request ID header, or by a
+// new UUID. The ID is echoed in the response's request ID header, logged with
+// the trace and span IDs, and carried by the request's context for RoundTrip
+// to forward.
 func (rl *RequestLogger) Handle(next http.Handler) http.Handler {
 	opts := RequestLoggerConfig{
 		Headers: rl.logHeaders,
@@ -77,13 +108,6 @@ func (rl *RequestLogger) Handle(next http.Handler) http.Handler {
 		Body:    rl.logBody,
 		GraphQL: rl.logGraphql,
 	}
-	logChan := make(chan requestLog)
-
-	go func() {
-		log := <-logChan
-		close(logChan)
-		rl.log(log)
-	}()
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log, err, statusCode := makeLog(r, opts)
@@ -93,12 +117,27 @@ func (rl *RequestLogger) Handle(next http.Handler) http.Handler {
 			return
 		}
 
+		id := r.Header.Get(rl.requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(rl.requestIDHeader, id)
+
+		tp := requestTraceParent(r)
+		fields := append([]Field{String(RequestIDKey, id)}, tp.Fields()...)
+		ctx := ContextWithRequestID(r.Context(), id)
+		ctx = ContextWithTraceParent(ctx, tp)
+		ctx = ContextWithFields(ctx, fields...)
+		r = r.WithContext(NewContext(ctx, rl.contextLogger.With(fields...)))
+
+		sr := &statusRecorder{ResponseWriter: w}
 		start := time.Now().UTC()
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(sr, r)
 		end := time.Now().UTC()
 		log.latency = end.Sub(start)
+		log.status = sr.statusCode()
 		log.contextError = r.Context().Err()
-		logChan <- log
+		rl.log(log, fields)
 	})
 }
 
@@ -148,6 +187,9 @@ func (rl requestLog) MarshalJSON() ([]byte, error) {
 	if len(rl.headers) > 0 {
 		obj["headers"] = rl.headers
 	}
+	if rl.status != 0 {
+		obj["status"] = rl.status
+	}
 	if err := rl.contextError; err != nil {
 		obj["cancelReason"] = err.Error()
 	}
@@ -187,8 +229,13 @@ func NewRequestLogger(config RequestLoggerConfig) *RequestLogger {
 	if config.ContextErrorLevel != logLevelUnset {
 		ctxErr = config.ContextErrorLevel
 	}
+	requestIDHeader := DefaultRequestIDHeader
+	if config.RequestIDHeader != "" {
+		requestIDHeader = http.CanonicalHeaderKey(config.RequestIDHeader)
+	}
 	return &RequestLogger{
 		logger:                sl,
+		contextLogger:         l.Sublogger(config.Tags...),
 		client:                config.Client,
 		logHeaders:            config.Headers,
 		logParams:             config.Params,
@@ -198,13 +245,14 @@ func NewRequestLogger(config RequestLoggerConfig) *RequestLogger {
 		deadlineExceededLevel: deadline,
 		contextCancelledLevel: cancelled,
 		contextErrorLevel:     ctxErr,
+		requestIDHeader:       requestIDHeader,
 	}
 }
 
 // MARK: Private Methods
 
-// log logs a requestLog with the RequestLogger's Logger
-func (rl *RequestLogger) log(log requestLog) {
+// log logs a requestLog and fields with the RequestLogger's Logger
+func (rl *RequestLogger) log(log requestLog, fields []Field) {
 	var ll Level
 	switch {
 	case errors.Is(log.contextError, context.DeadlineExceeded):
@@ -217,13 +265,24 @@ func (rl *RequestLogger) log(log requestLog) {
 		ll = rl.normalLevel
 	}
 
-	rl.logger.Logd(ll, log.label(), log)
+	l := rl.logger
+	if len(fields) > 0 {
+		l = l.With(fields...)
+	}
+	l.Logd(ll, log.label(), log)
 }
 
 func makeLog(r *http.Request, opts RequestLoggerConfig) (requestLog, error, int) {
 	log := requestLog{
-		method: r.Method,
-		path:   r.URL.Path,
+		method:    r.Method,
+		path:      r.URL.Path,
+		url:       r.URL.String(),
+		protocol:  r.Proto,
+		userAgent: r.UserAgent(),
+		remoteIP:  r.RemoteAddr,
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		log.remoteIP = host
 	}
 
 	if opts.Headers {
@@ -302,3 +361,50 @@ func (rl requestLog) label() string {
 	}
 	return label
 }
+
+// WriteHeader records the status code and writes it to the ResponseWriter
+func (sr *statusRecorder) WriteHeader(statusCode int) {
+	if sr.status == 0 {
+		sr.status = statusCode
+	}
+	sr.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write records an implicit 200 status code if none has been written, and
+// writes b to the ResponseWriter
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	if sr.status == 0 {
+		sr.status = http.StatusOK
+	}
+	return sr.ResponseWriter.Write(b)
+}
+
+// Flush flushes the ResponseWriter if it supports flushing
+func (sr *statusRecorder) Flush() {
+	if f, ok := sr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hijacks the ResponseWriter's connection if it supports hijacking
+func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := sr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the ResponseWriter for http.ResponseController
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// statusCode returns the status code written, or 200 if the handler didn't
+// write a response
+func (sr *statusRecorder) statusCode() int {
+	if sr.status == 0 {
+		return http.StatusOK
+	}
+	return sr.status
+}
@@ -23,6 +23,7 @@ func (rl *RequestLogger) RoundTripper(client *http.Client) http.RoundTripper {
 }
 
 func (rt roundTripper) RoundTrip(req *http.Request) (res *http.Response, err error) {
+	req, fields := rt.outboundRequest(req)
 	log, err, _ := makeLog(req, RequestLoggerConfig{
 		Headers: rt.RequestLogger.logHeaders,
 		Params:  rt.RequestLogger.logParams,
@@ -36,8 +37,11 @@ func (rt roundTripper) RoundTrip(req *http.Request) (res *http.Response, err err
 	start := time.Now()
 	res, err = rt.Client.Do(req)
 	log.latency = time.Since(start)
+	if res != nil {
+		log.status = res.StatusCode
+	}
 	log.contextError = req.Context().Err()
-	rt.log(log)
+	rt.log(log, fields)
 
 	return
 }
@@ -47,6 +51,7 @@ func (rl *RequestLogger) RoundTrip(req *http.Request) (res *http.Response, err e
 	if client == nil {
 		client = http.DefaultClient
 	}
+	req, fields := rl.outboundRequest(req)
 	log, err, _ := makeLog(req, RequestLoggerConfig{
 		Headers: rl.logHeaders,
 		Params:  rl.logParams,
@@ -60,12 +65,62 @@ func (rl *RequestLogger) RoundTrip(req *http.Request) (res *http.Response, err e
 	start := time.Now()
 	res, err = rl.client.Do(req)
 	log.latency = time.Since(start)
+	if res != nil {
+		log.status = res.StatusCode
+	}
 	log.contextError = req.Context().Err()
-	rl.log(log)
+	rl.log(log, fields)
 
 	return
 }
 
+// MARK: Private Functions
+
+// outboundRequest returns the request with the headers that propagate the
+// request context's trace and request ID, and the fields to log it with. The
+// traceparent header is for a child span of the context's TraceParent, or of
+// a new trace. Headers the request already has are kept.
+func (rl *RequestLogger) outboundRequest(req *http.Request) (*http.Request, []Field) {
+	headers := make(http.Header)
+
+	tp, ok := ParseTraceParent(req.Header.Get(TraceParentHeader))
+	if !ok {
+		if parent, ok := TraceParentFromContext(req.Context()); ok {
+			tp = parent.Child()
+		} else {
+			tp = NewTraceParent()
+		}
+		headers.Set(TraceParentHeader, tp.String())
+	}
+
+	var fields []Field
+	id := req.Header.Get(rl.requestIDHeader)
+	if id == "" {
+		id, _ = RequestIDFromContext(req.Context())
+		if id != "" {
+			headers.Set(rl.requestIDHeader, id)
+		}
+	}
+	if id != "" {
+		fields = append(fields, String(RequestIDKey, id))
+	}
+	fields = append(fields, tp.Fields()...)
+
+	if len(headers) == 0 {
+		return req, fields
+	}
+
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	if req.Header == nil {
+		req.Header = make(http.Header, len(headers))
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+	return req, fields
+}
+
 // MARK: Private Types
 
 type roundTripper struct {
@@ -0,0 +1,285 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// Levels of slog records that map to the trace and fatal levels, which slog
+// doesn't define
+const (
+	SlogLevelTrace = slog.Level(-8)
+	SlogLevelFatal = slog.Level(12)
+)
+
+// tagsKey is the key of the attribute that carries a message's tags
+const tagsKey = "tags"
+
+// MARK: Types
+
+// slogHandler is a slog.Handler that writes records with a Logger
+type slogHandler struct {
+	logger   Logger
+	groups   []string
+	metadata map[string]interface{}
+}
+
+// slogWriter is a messageWriter that forwards messages to a slog.Handler
+type slogWriter struct {
+	handler slog.Handler
+}
+
+// slogAttrs collects slog attributes as a message's fields, tags and metadata
+type slogAttrs struct {
+	fields   []Field
+	tags     []string
+	metadata map[string]interface{}
+}
+
+// MARK: Public Functions
+
+// NewSlogHandler returns a slog.Handler that writes records with the Logger,
+// or the default logger if l is nil, so they are written exactly like the
+// Logger's own messages. Records at SlogLevelFatal and above are written at
+// the fatal level without exiting.
+//
+// Attributes are written as fields, and an attribute with the key "tags" and a
+// []string value adds tags. Attributes in groups, including every attribute
+// added after WithGroup, are written as metadata nested by group. Fields
+// carried by the context passed to the handler are written first.
+func NewSlogHandler(l Logger) slog.Handler {
+	if l == nil {
+		l = LoggerSingleton
+	}
+	return &slogHandler{logger: l}
+}
+
+// NewSlogLogger returns a Logger that forwards messages to the slog.Handler.
+// Tags are forwarded as a "tags" attribute, fields as attributes, and data as
+// a "metadata" attribute. The handler's minimum level is read when the Logger
+// is created.
+func NewSlogLogger(h slog.Handler) Logger {
+	level := LogLevelFatal
+	for l := LogLevelTrace; l < LogLevelFatal; l++ {
+		if h.Enabled(context.Background(), slogLevel(l)) {
+			level = l
+			break
+		}
+	}
+
+	l := newLogger(LoggerConfig{Level: level, LogCaller: true})
+	l.messages = slogWriter{handler: h}
+	return l
+}
+
+// MARK: Handler Methods
+
+// Enabled reports whether the logger logs the level
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(levelFromSlog(level))
+}
+
+// Handle writes the record with the logger
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	level := levelFromSlog(r.Level)
+	if !h.logger.Enabled(level) {
+		return nil
+	}
+
+	contextFields := ContextFields(ctx)
+	attrs := slogAttrs{
+		fields:   make([]Field, 0, len(contextFields)+r.NumAttrs()),
+		metadata: cloneMetadata(h.metadata),
+	}
+	attrs.fields = append(attrs.fields, contextFields...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs.add(h.groups, a)
+		return true
+	})
+
+	var data interface{}
+	if len(attrs.metadata) > 0 {
+		data = attrs.metadata
+	}
+	m := h.logger.newLogMessage(r.Message, level, 0, data)
+	m.callFields = attrs.fields
+	if len(attrs.tags) > 0 {
+		m.Tags = append(m.Tags[:len(m.Tags):len(m.Tags)], attrs.tags...)
+	}
+	if m.logCaller {
+		// the caller is the record's, and a record without one has none
+		m.pc = r.PC
+		m.logCaller = r.PC != 0
+		if m.logCaller {
+			frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+			m.File = callerFile(frame.File, frame.Line, frame.File != "")
+		}
+	}
+	h.logger.write(m)
+	return nil
+}
+
+// WithAttrs returns a handler whose logger logs the attributes with every
+// message
+func (h *slogHandler) WithAttrs(as []slog.Attr) slog.Handler {
+	if len(as) == 0 {
+		return h
+	}
+
+	attrs := slogAttrs{metadata: cloneMetadata(h.metadata)}
+	for _, a := range as {
+		attrs.add(h.groups, a)
+	}
+
+	l := h.logger
+	if len(attrs.tags) > 0 {
+		l = l.Sublogger(attrs.tags...)
+	}
+	if len(attrs.fields) > 0 {
+		l = l.With(attrs.fields...)
+	}
+	return &slogHandler{logger: l, groups: h.groups, metadata: attrs.metadata}
+}
+
+// WithGroup returns a handler that nests later attributes in the group
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{
+		logger:   h.logger,
+		groups:   append(h.groups[:len(h.groups):len(h.groups)], name),
+		metadata: h.metadata,
+	}
+}
+
+// MARK: Private Methods
+
+// writeMessage forwards the message to the handler as a record
+func (w slogWriter) writeMessage(m *logMessage) {
+	ctx := context.Background()
+	level := slogLevel(m.rawLevel)
+	if !w.handler.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(m.time, level, m.Message, m.pc)
+	if len(m.Tags) > 0 {
+		r.AddAttrs(slog.Any(tagsKey, m.Tags))
+	}
+	for _, f := range m.allFields() {
+		r.AddAttrs(slog.Any(f.Key, fieldValue(f.Value)))
+	}
+	if m.Metadata != nil {
+		r.AddAttrs(slog.Any("metadata", m.Metadata))
+	}
+	_ = w.handler.Handle(ctx, r)
+}
+
+// add adds an attribute in the open groups. Attributes outside groups are
+// fields or tags, and attributes in groups are nested metadata. Empty
+// attributes and groups are ignored, and the attributes of a group without a
+// key are added to the open groups, as slog.Handler requires.
+func (as *slogAttrs) add(groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			as.add(groups, ga)
+		}
+		return
+	}
+
+	if len(groups) == 0 {
+		if tags, ok := a.Value.Any().([]string); ok && a.Key == tagsKey {
+			as.tags = append(as.tags, tags...)
+			return
+		}
+		as.fields = append(as.fields, Field{Key: a.Key, Value: slogValue(a.Value)})
+		return
+	}
+
+	if as.metadata == nil {
+		as.metadata = make(map[string]interface{})
+	}
+	group := as.metadata
+	for _, g := range groups {
+		child, ok := group[g].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			group[g] = child
+		}
+		group = child
+	}
+	group[a.Key] = fieldValue(slogValue(a.Value))
+}
+
+// MARK: Private Functions
+
+// slogValue returns the value of a resolved, non-group slog.Value. Durations
+// are strings such as "1.5s", like Duration fields.
+func slogValue(v slog.Value) interface{} {
+	if v.Kind() == slog.KindDuration {
+		return v.Duration().String()
+	}
+	return v.Any()
+}
+
+// cloneMetadata returns a copy of group metadata and the groups nested in it
+func cloneMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		if group, ok := v.(map[string]interface{}); ok {
+			v = cloneMetadata(group)
+		}
+		clone[k] = v
+	}
+	return clone
+}
+
+// slogLevel returns the slog level of a Level
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LogLevelTrace:
+		return SlogLevelTrace
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelInfo:
+		return slog.LevelInfo
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	case LogLevelFatal:
+		return SlogLevelFatal
+	}
+	return slog.LevelInfo
+}
+
+// levelFromSlog returns the Level a slog level is logged at. Levels between
+// the slog levels are rounded down.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LogLevelTrace
+	case level < slog.LevelInfo:
+		return LogLevelDebug
+	case level < slog.LevelWarn:
+		return LogLevelInfo
+	case level < slog.LevelError:
+		return LogLevelWarn
+	case level < SlogLevelFatal:
+		return LogLevelError
+	}
+	return LogLevelFatal
+}
@@ -0,0 +1,228 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slogTestLine returns the line of slog_test.go with the number
+func slogTestLine(t *testing.T, n int) string {
+	t.Helper()
+	source, err := os.ReadFile("slog_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(source), "\n")
+	if n < 1 || n > len(lines) {
+		t.Fatalf("no line %d in slog_test.go", n)
+	}
+	return lines[n-1]
+}
+
+func TestSlogHandler(t *testing.T) {
+	// a year-only time format makes timestamps of both lines equal
+	newLogger := func(format Format, buf *syncBuffer) Logger {
+		return NewLogger(LoggerConfig{
+			Level:      LogLevelTrace,
+			Format:     format,
+			TimeFormat: "2006",
+			LogCaller:  true,
+			Tags:       []string{"test"},
+			Output:     buf,
+		})
+	}
+	line := regexp.MustCompile(`slog_test\.go:(\d+)`)
+
+	// call is the slog method called on the line slog logs from
+	tests := []struct {
+		name   string
+		call   string
+		slog   func(l *slog.Logger)
+		native func(l Logger)
+	}{
+		{
+			name:   "Fields",
+			call:   "Info",
+			slog:   func(l *slog.Logger) { l.Info("Lot opened.", "lot", 7, "open", true, "wait", time.Second) },
+			native: func(l Logger) { l.Infow("Lot opened.", "lot", 7, "open", true, "wait", time.Second.String()) },
+		},
+		{
+			name: "Levels",
+			call: "Log",
+			slog: func(l *slog.Logger) {
+				l.Log(context.Background(), SlogLevelTrace, "Lot closed.", "err", errors.New("full"))
+			},
+			native: func(l Logger) { l.Tracew("Lot closed.", "err", errors.New("full")) },
+		},
+		{
+			name:   "With",
+			call:   "Warn",
+			slog:   func(l *slog.Logger) { l.With("tags", []string{"lots"}, "requestId", "abc").Warn("Lot full.") },
+			native: func(l Logger) { l.Sublogger("lots").WithField("requestId", "abc").Warnw("Lot full.") },
+		},
+		{
+			name: "Groups",
+			call: "Error",
+			slog: func(l *slog.Logger) {
+				l.WithGroup("lot").With("id", 7).Error("Gate stuck.", slog.Group("gate", "id", 2), "open", false)
+			},
+			native: func(l Logger) {
+				l.Errord("Gate stuck.", map[string]interface{}{
+					"lot": map[string]interface{}{"id": 7, "open": false, "gate": map[string]interface{}{"id": 2}},
+				})
+			},
+		},
+		{
+			name:   "Empty Group",
+			call:   "Info",
+			slog:   func(l *slog.Logger) { l.WithGroup("lot").Info("Lot opened.", slog.Group("gate")) },
+			native: func(l Logger) { l.Infoln("Lot opened.") },
+		},
+	}
+
+	for _, format := range []Format{LogFormatJSON, LogFormatPretty} {
+		for _, tt := range tests {
+			t.Run(string(format)+" "+tt.name, func(t *testing.T) {
+				var got, want syncBuffer
+				tt.slog(slog.New(NewSlogHandler(newLogger(format, &got))))
+				tt.native(newLogger(format, &want))
+
+				match := line.FindStringSubmatch(got.String())
+				if match == nil {
+					t.Fatalf("expected the slog caller in %q", got.String())
+				}
+				n, _ := strconv.Atoi(match[1])
+				if source := slogTestLine(t, n); !strings.Contains(source, "."+tt.call+"(") {
+					t.Errorf("expected the caller to call %s, got %q", tt.call, source)
+				}
+				g := line.ReplaceAllString(got.String(), "slog_test.go")
+				w := line.ReplaceAllString(want.String(), "slog_test.go")
+				if g != w {
+					t.Errorf("got %q, want %q", g, w)
+				}
+			})
+		}
+	}
+
+	t.Run("Context Fields", func(t *testing.T) {
+		var buf syncBuffer
+		l := slog.New(NewSlogHandler(NewLogger(LoggerConfig{Format: LogFormatLogfmt, Output: &buf})))
+		l.InfoContext(ContextWithFields(context.Background(), String("requestId", "abc")), "Lot opened.", "lot", 7)
+
+		if got := buf.String(); !strings.HasSuffix(got, "message=\"Lot opened.\" requestId=abc lot=7\n") {
+			t.Errorf("unexpected line %q", got)
+		}
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		h := NewSlogHandler(NewLogger(LoggerConfig{Level: LogLevelWarn, Output: &syncBuffer{}}))
+		if h.Enabled(context.Background(), slog.LevelInfo) || !h.Enabled(context.Background(), slog.LevelWarn+1) {
+			t.Error("expected levels from warn to be enabled")
+		}
+	})
+}
+
+func TestSlogLevels(t *testing.T) {
+	tests := []struct {
+		slog  slog.Level
+		level Level
+	}{
+		{SlogLevelTrace - 1, LogLevelTrace},
+		{SlogLevelTrace, LogLevelTrace},
+		{slog.LevelDebug, LogLevelDebug},
+		{slog.LevelInfo, LogLevelInfo},
+		{slog.LevelInfo + 2, LogLevelInfo},
+		{slog.LevelWarn, LogLevelWarn},
+		{slog.LevelError, LogLevelError},
+		{SlogLevelFatal, LogLevelFatal},
+		{SlogLevelFatal + 4, LogLevelFatal},
+	}
+	for _, tt := range tests {
+		if got := levelFromSlog(tt.slog); got != tt.level {
+			t.Errorf("levelFromSlog(%v) = %v, want %v", tt.slog, got, tt.level)
+		}
+	}
+	for l := LogLevelTrace; l <= LogLevelFatal; l++ {
+		if got := levelFromSlog(slogLevel(l)); got != l {
+			t.Errorf("%v didn't round trip, got %v", l, got)
+		}
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true}))
+
+	if l.Enabled(LogLevelTrace) || !l.Enabled(LogLevelDebug) {
+		t.Error("expected the handler's level")
+	}
+	l.Traceln("dropped")
+	l.Sublogger("lots").Infow("Lot opened.", "lot", 7)
+	l.WithField("requestId", "abc").Debugd("Lot state.", map[string]int{"spaces": 120})
+	l.Warnln("Lot closing.")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+
+	type source struct {
+		File string
+		Line int
+	}
+	var entry struct {
+		Level     string
+		Msg       string
+		Tags      []string
+		Lot       int
+		Source    source
+		Metadata  map[string]int
+		RequestID string `json:"requestId"`
+	}
+	// checkSource checks that the entry was logged from slog_test.go by a line
+	// that makes the call
+	checkSource := func(s source, call string) {
+		t.Helper()
+		if !strings.HasSuffix(s.File, "slog_test.go") {
+			t.Fatalf("unexpected source %+v", s)
+		}
+		if line := slogTestLine(t, s.Line); !strings.Contains(line, "."+call+"(") {
+			t.Errorf("expected the source to call %s, got %q", call, line)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[0], err)
+	}
+	if entry.Level != "INFO" || entry.Msg != "Lot opened." || len(entry.Tags) != 1 || entry.Tags[0] != "lots" ||
+		entry.Lot != 7 {
+		t.Errorf("unexpected entry %+v from %q", entry, lines[0])
+	}
+	checkSource(entry.Source, "Infow")
+
+	entry.Tags = nil
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[1], err)
+	}
+	if entry.Level != "DEBUG" || entry.Tags != nil || entry.Metadata["spaces"] != 120 || entry.RequestID != "abc" {
+		t.Errorf("unexpected entry %+v from %q", entry, lines[1])
+	}
+	checkSource(entry.Source, "Debugd")
+
+	if err := json.Unmarshal([]byte(lines[2]), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[2], err)
+	}
+	if entry.Level != "WARN" || entry.Msg != "Lot closing." {
+		t.Errorf("unexpected entry %+v from %q", entry, lines[2])
+	}
+	checkSource(entry.Source, "Warnln")
+}
@@ -0,0 +1,160 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	stdlog "log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MARK: Types
+
+// lineWriter is an io.Writer that logs each line written as a message
+type lineWriter struct {
+	logger Logger
+	level  Level
+	tags   []string
+
+	// mu guards partial, the start of a line that hasn't been terminated by a
+	// newline yet
+	mu      sync.Mutex
+	partial []byte
+}
+
+// MARK: Public Functions
+
+// NewWriter returns an io.Writer that logs each line written as a message at
+// the level with the tags, using the Logger, or the default logger if l is
+// nil. A line that isn't terminated by a newline is kept until a later write
+// completes it. A date, time and file written by a standard library logger are
+// removed, and the file is logged as the caller if the logger logs the caller.
+// Writes at the fatal level don't exit.
+func NewWriter(l Logger, level Level, tags ...string) io.Writer {
+	return &lineWriter{logger: l, level: level, tags: tags}
+}
+
+// NewStdLogger returns a standard library logger that logs each line as a
+// message at the level with the tags, using the Logger, or the default logger
+// if l is nil. It can be used where dependencies take a *log.Logger, such as
+// http.Server.ErrorLog.
+func NewStdLogger(l Logger, level Level, tags ...string) *stdlog.Logger {
+	return stdlog.New(NewWriter(l, level, tags...), "", stdlog.Lshortfile)
+}
+
+// RedirectStdLog redirects the standard library's default logger, used by
+// functions like log.Printf, to log each line as a message at the level with
+// the tags, using the default logger. It returns a function that restores the
+// standard library logger's previous output, prefix and flags.
+func RedirectStdLog(level Level, tags ...string) func() {
+	output, prefix, flags := stdlog.Writer(), stdlog.Prefix(), stdlog.Flags()
+	stdlog.SetOutput(NewWriter(nil, level, tags...))
+	stdlog.SetPrefix("")
+	stdlog.SetFlags(stdlog.Lshortfile)
+
+	return func() {
+		stdlog.SetOutput(output)
+		stdlog.SetPrefix(prefix)
+		stdlog.SetFlags(flags)
+	}
+}
+
+// MARK: Methods
+
+// Write logs each line of p as a message, keeping a trailing partial line
+// until a later write completes it
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	rest := w.partial
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(string(rest[:i]))
+		rest = rest[i+1:]
+	}
+	w.partial = append(w.partial[:0], rest...)
+	return len(p), nil
+}
+
+// logLine logs a line, without its newline, as a message
+func (w *lineWriter) logLine(line string) {
+	l := w.logger
+	if l == nil {
+		l = LoggerSingleton
+	}
+	if !l.Enabled(w.level) {
+		return
+	}
+
+	message, file := parseStdLogLine(line)
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+
+	m := l.newLogMessage(message, w.level, 0, nil)
+	if len(w.tags) > 0 {
+		m.Tags = append(m.Tags[:len(m.Tags):len(m.Tags)], w.tags...)
+	}
+	if m.logCaller {
+		// the caller is the one the standard library logger wrote, if any
+		m.File = file
+		m.logCaller = file != ""
+	}
+	l.write(m)
+}
+
+// MARK: Private Functions
+
+// parseStdLogLine returns the message of a line written by a standard library
+// logger, without a trailing newline or the date, time and file the logger's
+// flags add, and the "file:line" it was logged from, if written
+func parseStdLogLine(line string) (message, file string) {
+	line = strings.TrimSuffix(line, "\n")
+
+	// 2009/01/23
+	if len(line) > 10 && line[4] == '/' && line[7] == '/' && line[10] == ' ' &&
+		digits(line[0:4]) && digits(line[5:7]) && digits(line[8:10]) {
+		line = line[11:]
+	}
+
+	// 01:23:23, optionally with microseconds
+	if len(line) > 8 && line[2] == ':' && line[5] == ':' &&
+		digits(line[0:2]) && digits(line[3:5]) && digits(line[6:8]) {
+		end := 8
+		if len(line) > 15 && line[8] == '.' && digits(line[9:15]) {
+			end = 15
+		}
+		if line[end] == ' ' {
+			line = line[end+1:]
+		}
+	}
+
+	// /a/b/c/d.go:23 or d.go:23
+	if i := strings.Index(line, ": "); i > 0 {
+		caller := line[:i]
+		if j := strings.LastIndexByte(caller, ':'); j > 0 && strings.HasSuffix(caller[:j], ".go") &&
+			!strings.ContainsAny(caller, " \t") {
+			if n, err := strconv.Atoi(caller[j+1:]); err == nil {
+				return line[i+2:], callerFile(caller[:j], n, true)
+			}
+		}
+	}
+
+	return line, ""
+}
+
+// digits reports whether s is all ASCII digits
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	stdlog "log"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParseStdLogLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		message string
+		file    string
+	}{
+		{"http: TLS handshake error\n", "http: TLS handshake error", ""},
+		{"2009/01/23 01:23:23 message\n", "message", ""},
+		{"2009/01/23 01:23:23.123123 /a/b/server.go:23: message: detail\n", "message: detail", "server.go:23"},
+		{"01:23:23 server.go:23: message\n", "message", "server.go:23"},
+		{"server.go:x: message", "server.go:x: message", ""},
+		{"a message: with colon", "a message: with colon", ""},
+		{"line one\nline two\n", "line one\nline two", ""},
+	}
+
+	for _, tt := range tests {
+		message, file := parseStdLogLine(tt.line)
+		if message != tt.message || file != tt.file {
+			t.Errorf("parseStdLogLine(%q) = %q, %q, want %q, %q", tt.line, message, file, tt.message, tt.file)
+		}
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf syncBuffer
+	l := NewLogger(LoggerConfig{Level: LogLevelInfo, Format: LogFormatLogfmt, LogCaller: true, Tags: []string{"api"}, Output: &buf})
+
+	NewStdLogger(l, LogLevelWarn, "http").Printf("TLS handshake error from %s", "10.0.0.1")
+	NewStdLogger(l, LogLevelDebug).Println("dropped")
+	_, _ = NewWriter(l, LogLevelError).Write([]byte("third-party output\n"))
+	_, _ = NewWriter(l, LogLevelError).Write([]byte("\n"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []*regexp.Regexp{
+		regexp.MustCompile(`^timestamp=\S+ level=WARN tags=api,http file=stdlog_test.go:\d+ message="TLS handshake error from 10.0.0.1"$`),
+		regexp.MustCompile(`^timestamp=\S+ level=ERROR tags=api message="third-party output"$`),
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+	for i, line := range lines {
+		if !want[i].MatchString(line) {
+			t.Errorf("got %q, want %s", line, want[i])
+		}
+	}
+}
+
+func TestWriterLines(t *testing.T) {
+	t.Run("Multiple Lines", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelInfo, Format: LogFormatLogfmt, Output: &buf})
+
+		_, _ = NewWriter(l, LogLevelWarn).Write([]byte("first line\n\nsecond line\n"))
+
+		got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(got) != 2 || !strings.HasSuffix(got[0], `message="first line"`) ||
+			!strings.HasSuffix(got[1], `message="second line"`) {
+			t.Errorf("expected a message per line, got %q", got)
+		}
+	})
+
+	t.Run("Split Line", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewLogger(LoggerConfig{Level: LogLevelInfo, Format: LogFormatLogfmt, Output: &buf})
+		w := NewWriter(l, LogLevelWarn)
+
+		_, _ = w.Write([]byte("partial "))
+		if buf.String() != "" {
+			t.Fatalf("expected a partial line to be kept, got %q", buf.String())
+		}
+		_, _ = w.Write([]byte("line\nnext "))
+		_, _ = w.Write([]byte("line\n"))
+
+		got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(got) != 2 || !strings.HasSuffix(got[0], `message="partial line"`) ||
+			!strings.HasSuffix(got[1], `message="next line"`) {
+			t.Errorf("expected the split lines to be joined, got %q", got)
+		}
+	})
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	var buf syncBuffer
+	SetupLoggerWithConfig(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, LogCaller: true, Output: &buf})
+	defer SetupLocalLogger(LogLevelDebug)
+
+	output, flags := stdlog.Writer(), stdlog.Flags()
+	restore := RedirectStdLog(LogLevelInfo, "stdlib")
+	stdlog.Printf("Lot %d opened.", 7)
+	restore()
+
+	got := strings.TrimSpace(buf.String())
+	if !regexp.MustCompile(`"level":"INFO","tags":\["stdlib"\],"message":"Lot 7 opened.","file":"stdlog_test.go:\d+"}$`).MatchString(got) {
+		t.Errorf("unexpected line %q", got)
+	}
+	if stdlog.Writer() != output || stdlog.Flags() != flags {
+		t.Error("expected the standard library logger to be restored")
+	}
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -10,6 +11,7 @@ import (
 type sublogger struct {
 	Logger
 	subTags    []string
+	fields     []Field
 	skipOffset int
 }
 
@@ -20,7 +22,7 @@ func Sublogger(tags ...string) Logger {
 	return &sublogger{
 		Logger:     LoggerSingleton,
 		subTags:    tags,
-		skipOffset: 0,
+		skipOffset: 1,
 	}
 }
 
@@ -33,15 +35,67 @@ func (sl *sublogger) Sublogger(tags ...string) Logger {
 	}
 }
 
+// With returns a new sublogger that logs the provided fields with every
+// message
+func (sl *sublogger) With(fields ...Field) Logger {
+	return &sublogger{
+		Logger:     sl,
+		fields:     fields,
+		skipOffset: 1,
+	}
+}
+
+// WithField returns a new sublogger that logs the provided key and value with
+// every message
+func (sl *sublogger) WithField(key string, value interface{}) Logger {
+	return sl.With(Field{Key: key, Value: value})
+}
+
+// Enabled reports whether messages at the level are logged
+func (sl *sublogger) Enabled(level Level) bool {
+	return sl.level() <= level
+}
+
 // MARK: base log methods
 
 // Logln prints the output followed by a newline
 func (sl *sublogger) Logln(level Level, message string) {
-	sl.printMessage(message, level, nil)
+	sl.logln(level, message)
 }
 
 // Logf prints the formatted output
 func (sl *sublogger) Logf(level Level, format string, a ...interface{}) {
+	sl.logf(level, format, a...)
+}
+
+// Logd prints output string and data
+func (sl *sublogger) Logd(level Level, message string, d interface{}) {
+	sl.logd(level, message, d)
+}
+
+// Logfn prints the output string and data returned by fn, which is only called
+// if the level is enabled
+func (sl *sublogger) Logfn(level Level, fn func() (string, interface{})) {
+	sl.logfn(level, fn)
+}
+
+// Logw prints output string and fields from alternating keys and values
+func (sl *sublogger) Logw(level Level, message string, keysAndValues ...interface{}) {
+	sl.logw(level, message, keysAndValues...)
+}
+
+// MARK: base log implementations
+
+// logln implements Logln and the *ln level methods
+func (sl *sublogger) logln(level Level, message string) {
+	sl.printMessage(message, level, nil)
+}
+
+// logf implements Logf and the *f level methods
+func (sl *sublogger) logf(level Level, format string, a ...interface{}) {
+	if sl.level() > level {
+		return
+	}
 	sl.printMessage(
 		fmt.Sprintf(format, a...),
 		level,
@@ -49,130 +103,295 @@ func (sl *sublogger) Logf(level Level, format string, a ...interface{}) {
 	)
 }
 
-// Logd prints output string and data
-func (sl *sublogger) Logd(level Level, message string, d interface{}) {
+// logd implements Logd and the *d level methods
+func (sl *sublogger) logd(level Level, message string, d interface{}) {
+	sl.printMessage(message, level, d)
+}
+
+// logfn implements Logfn and the *fn level methods
+func (sl *sublogger) logfn(level Level, fn func() (string, interface{})) {
+	if sl.level() > level {
+		return
+	}
+	message, d := fn()
 	sl.printMessage(message, level, d)
 }
 
+// logw implements Logw and the *w level methods
+func (sl *sublogger) logw(level Level, message string, keysAndValues ...interface{}) {
+	if sl.level() > level {
+		return
+	}
+	sl.printMessage(message, level, keyValueFields(keysAndValues))
+}
+
 // MARK: Trace
 
 // Traceln prints the output followed by a newline
 func (sl *sublogger) Traceln(message string) {
-	sl.Logln(LogLevelTrace, message)
+	sl.logln(LogLevelTrace, message)
 }
 
 // Tracef prints the formatted output
 func (sl *sublogger) Tracef(format string, a ...interface{}) {
-	sl.Logf(LogLevelTrace, format, a...)
+	sl.logf(LogLevelTrace, format, a...)
 }
 
 // Traced prints the output string and data
 func (sl *sublogger) Traced(message string, d interface{}) {
-	sl.Logd(LogLevelTrace, message, d)
+	sl.logd(LogLevelTrace, message, d)
+}
+
+// Tracew prints the output string and fields from alternating keys and values
+func (sl *sublogger) Tracew(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelTrace, message, keysAndValues...)
+}
+
+// Tracefn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Tracefn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelTrace, fn)
 }
 
 // MARK: Debug
 
 // Debugln prints the output followed by a newline
 func (sl *sublogger) Debugln(message string) {
-	sl.Logln(LogLevelDebug, message)
+	sl.logln(LogLevelDebug, message)
 }
 
 // Debugf prints the formatted output
 func (sl *sublogger) Debugf(format string, a ...interface{}) {
-	sl.Logf(LogLevelDebug, format, a...)
+	sl.logf(LogLevelDebug, format, a...)
 }
 
 // Debugd prints the output string and data
 func (sl *sublogger) Debugd(message string, d interface{}) {
-	sl.Logd(LogLevelDebug, message, d)
+	sl.logd(LogLevelDebug, message, d)
+}
+
+// Debugw prints the output string and fields from alternating keys and values
+func (sl *sublogger) Debugw(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelDebug, message, keysAndValues...)
+}
+
+// Debugfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Debugfn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelDebug, fn)
 }
 
 // MARK: Info
 
 // Infoln prints the output followed by a newline
 func (sl *sublogger) Infoln(message string) {
-	sl.Logln(LogLevelInfo, message)
+	sl.logln(LogLevelInfo, message)
 }
 
 // Infof prints the formatted output
 func (sl *sublogger) Infof(format string, a ...interface{}) {
-	sl.Logf(LogLevelInfo, format, a...)
+	sl.logf(LogLevelInfo, format, a...)
 }
 
 // Infod prints the output string and data
 func (sl *sublogger) Infod(message string, d interface{}) {
-	sl.Logd(LogLevelInfo, message, d)
+	sl.logd(LogLevelInfo, message, d)
+}
+
+// Infow prints the output string and fields from alternating keys and values
+func (sl *sublogger) Infow(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelInfo, message, keysAndValues...)
+}
+
+// Infofn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Infofn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelInfo, fn)
 }
 
 // MARK: Warn
 
 // Warnln prints the output followed by a newline
 func (sl *sublogger) Warnln(message string) {
-	sl.Logln(LogLevelWarn, message)
+	sl.logln(LogLevelWarn, message)
 }
 
 // Warnf prints the formatted output
 func (sl *sublogger) Warnf(format string, a ...interface{}) {
-	sl.Logf(LogLevelWarn, format, a...)
+	sl.logf(LogLevelWarn, format, a...)
 }
 
 // Warnd prints the output string and data
 func (sl *sublogger) Warnd(message string, d interface{}) {
-	sl.Logd(LogLevelWarn, message, d)
+	sl.logd(LogLevelWarn, message, d)
+}
+
+// Warnw prints the output string and fields from alternating keys and values
+func (sl *sublogger) Warnw(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelWarn, message, keysAndValues...)
+}
+
+// Warnfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Warnfn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelWarn, fn)
 }
 
 // MARK: Error
 
 // Errorln prints the output followed by a newline
 func (sl *sublogger) Errorln(message string) {
-	sl.Logln(LogLevelError, message)
+	sl.logln(LogLevelError, message)
 }
 
 // Errorf prints the formatted output
 func (sl *sublogger) Errorf(format string, a ...interface{}) {
-	sl.Logf(LogLevelError, format, a...)
+	sl.logf(LogLevelError, format, a...)
 }
 
 // Errord prints the output string and data
 func (sl *sublogger) Errord(message string, d interface{}) {
-	sl.Logd(LogLevelError, message, d)
+	sl.logd(LogLevelError, message, d)
+}
+
+// Errorw prints the output string and fields from alternating keys and values
+func (sl *sublogger) Errorw(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelError, message, keysAndValues...)
+}
+
+// Errorfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Errorfn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelError, fn)
 }
 
 // MARK: Fatal
 
 // Fatalln prints the output followed by a newline
 func (sl *sublogger) Fatalln(message string) {
-	sl.Logln(LogLevelFatal, message)
+	sl.logln(LogLevelFatal, message)
 	sl.exit()
 }
 
 // Fatalf prints the formatted output
 func (sl *sublogger) Fatalf(format string, a ...interface{}) {
-	sl.Logf(LogLevelFatal, format, a...)
+	sl.logf(LogLevelFatal, format, a...)
 	sl.exit()
 }
 
 // Fatald prints the output string and data
 func (sl *sublogger) Fatald(message string, d interface{}) {
-	sl.Logd(LogLevelFatal, message, d)
+	sl.logd(LogLevelFatal, message, d)
 	sl.exit()
 }
 
+// Fatalw prints the output string and fields from alternating keys and values
+func (sl *sublogger) Fatalw(message string, keysAndValues ...interface{}) {
+	sl.logw(LogLevelFatal, message, keysAndValues...)
+	sl.exit()
+}
+
+// Fatalfn prints the output string and data returned by fn, which is only
+// called if the level is enabled
+func (sl *sublogger) Fatalfn(fn func() (string, interface{})) {
+	sl.logfn(LogLevelFatal, fn)
+	sl.exit()
+}
+
+// MARK: Context
+
+// LogCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) LogCtx(ctx context.Context, level Level, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, level, message, keysAndValues)
+}
+
+// TraceCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) TraceCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelTrace, message, keysAndValues)
+}
+
+// DebugCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) DebugCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelDebug, message, keysAndValues)
+}
+
+// InfoCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) InfoCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelInfo, message, keysAndValues)
+}
+
+// WarnCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) WarnCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelWarn, message, keysAndValues)
+}
+
+// ErrorCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values
+func (sl *sublogger) ErrorCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelError, message, keysAndValues)
+}
+
+// FatalCtx prints the output string and the fields carried by ctx followed by
+// fields from alternating keys and values, and calls os.Exit(1)
+func (sl *sublogger) FatalCtx(ctx context.Context, message string, keysAndValues ...interface{}) {
+	logContext(sl, ctx, LogLevelFatal, message, keysAndValues)
+	sl.exit()
+}
+
+// MARK: Events
+
+// Trace starts a trace level event
+func (sl *sublogger) Trace() *Event {
+	return newEvent(sl, LogLevelTrace)
+}
+
+// Debug starts a debug level event
+func (sl *sublogger) Debug() *Event {
+	return newEvent(sl, LogLevelDebug)
+}
+
+// Info starts an info level event
+func (sl *sublogger) Info() *Event {
+	return newEvent(sl, LogLevelInfo)
+}
+
+// Warn starts a warn level event
+func (sl *sublogger) Warn() *Event {
+	return newEvent(sl, LogLevelWarn)
+}
+
+// Error starts an error level event
+func (sl *sublogger) Error() *Event {
+	return newEvent(sl, LogLevelError)
+}
+
+// Fatal starts a fatal level event. Writing the event calls os.Exit(1).
+func (sl *sublogger) Fatal() *Event {
+	return newEvent(sl, LogLevelFatal)
+}
+
 // MARK: Private Methods
 
 // newLogMessage creates a new *logMessage
 func (sl *sublogger) newLogMessage(output string, level Level, skipOffset int, d interface{}) *logMessage {
 	m := sl.Logger.newLogMessage(output, level, sl.skipOffset+skipOffset, d)
-	m.Tags = append(m.Tags, sl.subTags...)
+	m.Tags = append(m.Tags[:len(m.Tags):len(m.Tags)], sl.subTags...)
+	if len(sl.fields) > 0 {
+		m.fields = append(m.fields[:len(m.fields):len(m.fields)], sl.fields...)
+	}
 	return m
 }
 
-// printMessage prints a logMessage to stdout
+// printMessage writes a logMessage to the parent logger's output
 func (sl *sublogger) printMessage(output string, level Level, d interface{}) {
 	if sl.level() > level {
 		return
 	}
 
-	fmt.Println(sl.newLogMessage(output, level, 0, d).String())
+	sl.write(sl.newLogMessage(output, level, 0, d))
 }
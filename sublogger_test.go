@@ -2,9 +2,20 @@ package log
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
+// tagsRecorder is a messageWriter that keeps the tags of each message without
+// copying them
+type tagsRecorder struct {
+	tags *[][]string
+}
+
+func (tr tagsRecorder) writeMessage(m *logMessage) {
+	*tr.tags = append(*tr.tags, m.Tags)
+}
+
 func TestSublogger(t *testing.T) {
 	LoggerSingleton = &logger{
 		rawLevel:       LogLevelDebug,
@@ -21,3 +32,23 @@ func TestSublogger(t *testing.T) {
 	sl.Debugln("sublogger")
 	sl2.Debugln("sub-sub-logger")
 }
+
+func TestSiblingSubloggerTags(t *testing.T) {
+	// spare capacity in the logger's tags must not be shared by subloggers
+	tags := make([]string, 1, 4)
+	tags[0] = "api"
+	var recorded [][]string
+	l := newLogger(LoggerConfig{Level: LogLevelDebug, Tags: tags})
+	l.messages = tagsRecorder{tags: &recorded}
+
+	l.Sublogger("a").Infoln("first")
+	l.Sublogger("b").Infoln("second")
+	l.Sublogger("c").Sublogger("d").Infoln("third")
+
+	want := []string{"api,a", "api,b", "api,c,d"}
+	for i, w := range want {
+		if got := strings.Join(recorded[i], ","); got != w {
+			t.Errorf("message %d has tags %q, want %q", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MARK: Types
+
+// SyslogFacility is the syslog facility code that log lines are sent with
+type SyslogFacility int
+
+const (
+	// SyslogFacilityUser is the user-level messages facility.
+	SyslogFacilityUser SyslogFacility = 1
+
+	// SyslogFacilityDaemon is the system daemons facility.
+	SyslogFacilityDaemon SyslogFacility = 3
+
+	// SyslogFacilityLocal0 through SyslogFacilityLocal7 are the locally used
+	// facilities.
+	SyslogFacilityLocal0 SyslogFacility = 16
+	SyslogFacilityLocal1 SyslogFacility = 17
+	SyslogFacilityLocal2 SyslogFacility = 18
+	SyslogFacilityLocal3 SyslogFacility = 19
+	SyslogFacilityLocal4 SyslogFacility = 20
+	SyslogFacilityLocal5 SyslogFacility = 21
+	SyslogFacilityLocal6 SyslogFacility = 22
+	SyslogFacilityLocal7 SyslogFacility = 23
+)
+
+// SyslogConfig defines the options for a SyslogWriter
+type SyslogConfig struct {
+	// Network is "tcp" or "unix" for stream sockets, or "udp" or "unixgram" for
+	// datagram sockets. Messages on stream sockets are framed with octet
+	// counting.
+	Network string
+
+	// Address is the address of the syslog server, or the path of its socket.
+	Address string
+
+	// Facility defaults to SyslogFacilityUser.
+	Facility SyslogFacility
+
+	// Hostname defaults to the host name reported by the kernel.
+	Hostname string
+
+	// AppName defaults to the name of the executable.
+	AppName string
+
+	// TagsAsAppName sends the tags of each line, joined with ".", as the
+	// APP-NAME instead of as structured data.
+	TagsAsAppName bool
+
+	// StructuredDataID is the SD-ID of the element the tags are sent in.
+	// Defaults to "tags@32473".
+	StructuredDataID string
+
+	// DialTimeout defaults to five seconds.
+	DialTimeout time.Duration
+
+	// MaxBackoff is the longest time to wait between reconnection attempts.
+	// Lines written while waiting to reconnect are dropped. Defaults to thirty
+	// seconds.
+	MaxBackoff time.Duration
+}
+
+// SyslogWriter is an io.Writer that sends log lines as RFC 5424 syslog
+// messages, with a severity mapped from the level of each line. The line itself
+// is sent as the MSG part.
+type SyslogWriter struct {
+	config   SyslogConfig
+	procID   string
+	framed   bool
+	minRetry time.Duration
+
+	mu        sync.Mutex
+	conn      net.Conn
+	backoff   time.Duration
+	nextRetry time.Time
+}
+
+// errSyslogNotConnected is returned while waiting to reconnect
+var errSyslogNotConnected = errors.New("syslog: not connected")
+
+// MARK: Public Functions
+
+// NewSyslogWriter returns a SyslogWriter for the configured server. Failing to
+// connect is not an error; the writer keeps trying to reconnect as lines are
+// written.
+func NewSyslogWriter(config SyslogConfig) (*SyslogWriter, error) {
+	switch config.Network {
+	case "udp", "udp4", "udp6", "unixgram":
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return nil, fmt.Errorf("syslog: unsupported network %q", config.Network)
+	}
+	if config.Facility == 0 {
+		config.Facility = SyslogFacilityUser
+	}
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+	if config.AppName == "" {
+		config.AppName = filepath.Base(os.Args[0])
+	}
+	if config.StructuredDataID == "" {
+		config.StructuredDataID = "tags@32473"
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+
+	sw := &SyslogWriter{
+		config:   config,
+		procID:   strconv.Itoa(os.Getpid()),
+		framed:   !strings.HasPrefix(config.Network, "udp") && config.Network != "unixgram",
+		minRetry: 100 * time.Millisecond,
+	}
+	sw.mu.Lock()
+	_ = sw.connect()
+	sw.mu.Unlock()
+	return sw, nil
+}
+
+// MARK: Public Methods
+
+// Write sends p as an informational message without tags
+func (sw *SyslogWriter) Write(p []byte) (int, error) {
+	return sw.WriteLevel(LogLevelInfo, nil, p)
+}
+
+// WriteLevel sends p with the severity for level and the given tags. If the
+// connection fails, it reconnects and tries once more.
+func (sw *SyslogWriter) WriteLevel(level Level, tags []string, p []byte) (int, error) {
+	msg := sw.message(time.Now(), level, tags, bytes.TrimRight(p, "\n"))
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	for attempt := 0; attempt < 2; attempt++ {
+		if sw.conn == nil {
+			if err := sw.connect(); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := sw.conn.Write(msg); err != nil {
+			_ = sw.conn.Close()
+			sw.conn = nil
+			if attempt == 0 {
+				continue
+			}
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return 0, errSyslogNotConnected
+}
+
+// Close closes the connection to the syslog server
+func (sw *SyslogWriter) Close() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.conn == nil {
+		return nil
+	}
+	err := sw.conn.Close()
+	sw.conn = nil
+	return err
+}
+
+// MARK: Private Methods
+
+// connect dials the syslog server unless it is waiting to retry after a failed
+// attempt, doubling the wait after each failure. The caller must hold sw.mu.
+func (sw *SyslogWriter) connect() error {
+	if time.Now().Before(sw.nextRetry) {
+		return errSyslogNotConnected
+	}
+
+	conn, err := net.DialTimeout(sw.config.Network, sw.config.Address, sw.config.DialTimeout)
+	if err != nil {
+		switch {
+		case sw.backoff == 0:
+			sw.backoff = sw.minRetry
+		case sw.backoff < sw.config.MaxBackoff:
+			sw.backoff *= 2
+		}
+		if sw.backoff > sw.config.MaxBackoff {
+			sw.backoff = sw.config.MaxBackoff
+		}
+		sw.nextRetry = time.Now().Add(sw.backoff)
+		return err
+	}
+
+	sw.conn = conn
+	sw.backoff = 0
+	sw.nextRetry = time.Time{}
+	return nil
+}
+
+// message returns the RFC 5424 message for a line, framed with its length for
+// stream sockets
+func (sw *SyslogWriter) message(t time.Time, level Level, tags []string, msg []byte) []byte {
+	appName := sw.config.AppName
+	structuredData := "-"
+	if len(tags) > 0 {
+		if sw.config.TagsAsAppName {
+			appName = strings.Join(tags, ".")
+		} else {
+			var sd strings.Builder
+			sd.WriteString("[" + sw.config.StructuredDataID)
+			for _, tag := range tags {
+				sd.WriteString(` tag="` + escapeSyslogParam(tag) + `"`)
+			}
+			sd.WriteString("]")
+			structuredData = sd.String()
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s - %s ",
+		int(sw.config.Facility)*8+level.syslogSeverity(),
+		t.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(sw.config.Hostname, 255),
+		syslogHeaderField(appName, 48),
+		sw.procID,
+		structuredData,
+	)
+	b.Write(msg)
+
+	if !sw.framed {
+		return b.Bytes()
+	}
+	return append([]byte(strconv.Itoa(b.Len())+" "), b.Bytes()...)
+}
+
+// MARK: Private Functions
+
+// syslogHeaderField returns s limited to the printable ASCII characters and
+// length allowed in a header field, or the nil value if it is empty
+func syslogHeaderField(s string, maxLen int) string {
+	var b strings.Builder
+	for i := 0; i < len(s) && b.Len() < maxLen; i++ {
+		if s[i] >= 33 && s[i] <= 126 {
+			b.WriteByte(s[i])
+		}
+	}
+	if b.Len() == 0 {
+		return "-"
+	}
+	return b.String()
+}
+
+// escapeSyslogParam escapes the characters that aren't allowed unescaped in a
+// structured data parameter value
+func escapeSyslogParam(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(s)
+}
@@ -0,0 +1,190 @@
+package log
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readFramed reads an octet-counted syslog message
+func readFramed(t *testing.T, r *bufio.Reader) string {
+	length, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		t.Fatalf("invalid frame length %q", length)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatal(err)
+	}
+	return string(msg)
+}
+
+func TestSyslogWriter(t *testing.T) {
+	header := regexp.MustCompile(`^<(\d+)>1 \S+Z test-host (\S+) \d+ - `)
+
+	t.Run("UDP", func(t *testing.T) {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer pc.Close()
+
+		sw, err := NewSyslogWriter(SyslogConfig{
+			Network:  "udp",
+			Address:  pc.LocalAddr().String(),
+			Facility: SyslogFacilityLocal0,
+			Hostname: "test-host",
+			AppName:  "some-api",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sw.Close()
+
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelDebug,
+			Format: LogFormatJSON,
+			Tags:   []string{"live", `quoted"tag`},
+			Output: sw,
+		})
+		l.Warnln("This is a warning.")
+
+		buf := make([]byte, 2048)
+		_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := string(buf[:n])
+
+		m := header.FindStringSubmatch(msg)
+		if m == nil {
+			t.Fatalf("invalid syslog header: %q", msg)
+		}
+		if m[1] != "132" { // local0 (16) * 8 + warning (4)
+			t.Errorf("expected PRI 132, got %s", m[1])
+		}
+		if m[2] != "some-api" {
+			t.Errorf("expected app name some-api, got %s", m[2])
+		}
+		rest := msg[len(m[0]):]
+		if !strings.HasPrefix(rest, `[tags@32473 tag="live" tag="quoted\"tag"] {"timestamp"`) {
+			t.Errorf("unexpected structured data and message: %q", rest)
+		}
+		if strings.HasSuffix(rest, "\n") {
+			t.Errorf("message should not end with a newline: %q", rest)
+		}
+	})
+
+	t.Run("TCP", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ln.Close()
+
+		sw, err := NewSyslogWriter(SyslogConfig{
+			Network:       "tcp",
+			Address:       ln.Addr().String(),
+			Hostname:      "test-host",
+			TagsAsAppName: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sw.Close()
+
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		l := NewLogger(LoggerConfig{
+			Level:  LogLevelTrace,
+			Format: LogFormatPretty,
+			Tags:   []string{"some-api", "develop"},
+			Output: sw,
+		})
+		l.Errorln("This is an error.")
+		l.Traceln("This is a trace statement.")
+
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		r := bufio.NewReader(conn)
+		for _, want := range []struct {
+			pri     string
+			message string
+		}{
+			{"11", "[ERROR] (some-api,develop) This is an error."},          // user (1) * 8 + error (3)
+			{"15", "[TRACE] (some-api,develop) This is a trace statement."}, // user (1) * 8 + debug (7)
+		} {
+			msg := readFramed(t, r)
+			m := header.FindStringSubmatch(msg)
+			if m == nil {
+				t.Fatalf("invalid syslog header: %q", msg)
+			}
+			if m[1] != want.pri || m[2] != "some-api.develop" {
+				t.Errorf("expected PRI %s and app name some-api.develop, got %s and %s", want.pri, m[1], m[2])
+			}
+			if !strings.HasPrefix(msg[len(m[0]):], "- ") || !strings.Contains(msg, want.message) {
+				t.Errorf("unexpected message: %q", msg)
+			}
+		}
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr := ln.Addr().String()
+		ln.Close()
+
+		sw, err := NewSyslogWriter(SyslogConfig{Network: "tcp", Address: addr, Hostname: "test-host"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sw.Close()
+		sw.minRetry = 10 * time.Millisecond
+
+		if _, err := sw.Write([]byte("dropped\n")); err == nil {
+			t.Fatal("expected an error while the server is down")
+		}
+
+		ln, err = net.Listen("tcp", addr)
+		if err != nil {
+			t.Skipf("could not listen on %s again: %v", addr, err)
+		}
+		defer ln.Close()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			if _, err := sw.Write([]byte("delivered\n")); err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("writer did not reconnect")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		if msg := readFramed(t, bufio.NewReader(conn)); !strings.HasSuffix(msg, " - - delivered") {
+			t.Errorf("unexpected message: %q", msg)
+		}
+	})
+}
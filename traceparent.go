@@ -0,0 +1,178 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// TraceParentHeader is the W3C Trace Context header that carries a
+// TraceParent between services
+const TraceParentHeader = "traceparent"
+
+// Keys of the fields that trace and span IDs are logged under
+const (
+	TraceIDKey = "traceId"
+	SpanIDKey  = "spanId"
+)
+
+// traceFlagSampled is the trace flag recording that the caller may have
+// sampled the trace
+const traceFlagSampled = 0x01
+
+// MARK: Types
+
+// TraceParent identifies a span of a W3C trace, as carried by the traceparent
+// header.
+type TraceParent struct {
+	// TraceID is the ID of the whole trace, as 32 lowercase hex characters.
+	TraceID string
+
+	// SpanID is the ID of the span, as 16 lowercase hex characters.
+	SpanID string
+
+	// Flags are the trace flags, such as whether the trace is sampled.
+	Flags byte
+}
+
+// MARK: Public Functions
+
+// ParseTraceParent parses the value of a traceparent header. It reports
+// whether the value is a valid traceparent.
+func ParseTraceParent(s string) (TraceParent, bool) {
+	// version-traceid-spanid-flags, with fields after flags allowed in future
+	// versions
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return TraceParent{}, false
+	}
+
+	version, ok := parseHexByte(s[0:2])
+	if !ok || version == 0xff || version == 0 && len(s) != 55 || len(s) > 55 && s[55] != '-' {
+		return TraceParent{}, false
+	}
+	flags, ok := parseHexByte(s[53:55])
+	if !ok {
+		return TraceParent{}, false
+	}
+
+	tp := TraceParent{TraceID: s[3:35], SpanID: s[36:52], Flags: flags}
+	if !validTraceID(tp.TraceID) || !validTraceID(tp.SpanID) {
+		return TraceParent{}, false
+	}
+	return tp, true
+}
+
+// NewTraceParent returns a TraceParent that starts a new, sampled trace
+func NewTraceParent() TraceParent {
+	return TraceParent{
+		TraceID: randomHex(16),
+		SpanID:  randomHex(8),
+		Flags:   traceFlagSampled,
+	}
+}
+
+// ContextWithTraceParent returns a copy of ctx that carries the TraceParent.
+// RoundTrip propagates it to outbound requests made with the context.
+func ContextWithTraceParent(ctx context.Context, tp TraceParent) context.Context {
+	return context.WithValue(ctx, traceParentContextKey, tp)
+}
+
+// TraceParentFromContext returns the TraceParent carried by ctx, if any
+func TraceParentFromContext(ctx context.Context) (TraceParent, bool) {
+	if ctx == nil {
+		return TraceParent{}, false
+	}
+	tp, ok := ctx.Value(traceParentContextKey).(TraceParent)
+	return tp, ok
+}
+
+// MARK: Methods
+
+// String returns the TraceParent as a version 00 traceparent header value
+func (tp TraceParent) String() string {
+	b := make([]byte, 0, 55)
+	b = append(b, "00-"...)
+	b = append(b, tp.TraceID...)
+	b = append(b, '-')
+	b = append(b, tp.SpanID...)
+	b = append(b, '-', hexDigits[tp.Flags>>4], hexDigits[tp.Flags&0xf])
+	return string(b)
+}
+
+// Child returns a TraceParent for a new span in the same trace
+func (tp TraceParent) Child() TraceParent {
+	return TraceParent{
+		TraceID: tp.TraceID,
+		SpanID:  randomHex(8),
+		Flags:   tp.Flags,
+	}
+}
+
+// Fields returns the trace and span IDs as fields
+func (tp TraceParent) Fields() []Field {
+	return []Field{
+		{Key: TraceIDKey, Value: tp.TraceID},
+		{Key: SpanIDKey, Value: tp.SpanID},
+	}
+}
+
+// MARK: Private Functions
+
+// requestTraceParent returns a TraceParent for a span handling the request,
+// continuing the trace of the request's traceparent header or starting a new
+// one
+func requestTraceParent(r *http.Request) TraceParent {
+	if parent, ok := ParseTraceParent(r.Header.Get(TraceParentHeader)); ok {
+		return parent.Child()
+	}
+	return NewTraceParent()
+}
+
+// parseHexByte parses two lowercase hex characters
+func parseHexByte(s string) (byte, bool) {
+	hi, ok1 := hexValue(s[0])
+	lo, ok2 := hexValue(s[1])
+	return hi<<4 | lo, ok1 && ok2
+}
+
+// hexValue returns the value of a lowercase hex character
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
+
+// validTraceID reports whether id is lowercase hex and not all zeros
+func validTraceID(id string) bool {
+	zero := true
+	for i := 0; i < len(id); i++ {
+		if _, ok := hexValue(id[i]); !ok {
+			return false
+		}
+		if id[i] != '0' {
+			zero = false
+		}
+	}
+	return !zero
+}
+
+// randomHex returns n random bytes as lowercase hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic("log: reading random trace ID: " + err.Error())
+		}
+		// an all zero ID is invalid
+		for _, c := range b {
+			if c != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
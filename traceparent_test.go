@@ -0,0 +1,136 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  TraceParent
+		ok    bool
+	}{
+		{
+			name:  "Valid",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:  TraceParent{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Flags: 1},
+			ok:    true,
+		},
+		{
+			name:  "Future Version",
+			value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future",
+			want:  TraceParent{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"},
+			ok:    true,
+		},
+		{name: "Empty", value: ""},
+		{name: "Trailing Data", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-x"},
+		{name: "Invalid Version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "Uppercase", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "Zero Trace ID", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "Zero Span ID", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "Bad Separator", value: "00-4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTraceParent(tt.value)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseTraceParent(%q) = %+v, %t, want %+v, %t", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	tp := NewTraceParent()
+	parsed, ok := ParseTraceParent(tp.String())
+	if !ok || parsed != tp {
+		t.Fatalf("%q didn't round trip: %+v", tp.String(), parsed)
+	}
+
+	child := tp.Child()
+	if child.TraceID != tp.TraceID || child.SpanID == tp.SpanID || child.Flags != tp.Flags {
+		t.Errorf("unexpected child %+v of %+v", child, tp)
+	}
+}
+
+func TestRequestLogger_Trace(t *testing.T) {
+	const incoming = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var upstream string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream = r.Header.Get(TraceParentHeader)
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	rl := NewRequestLogger(RequestLoggerConfig{
+		Logger: NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf}),
+		Client: server.Client(),
+	})
+
+	var handlerSpan TraceParent
+	h := rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan, _ = TraceParentFromContext(r.Context())
+		FromContext(r.Context()).Infoln("handling")
+
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, server.URL, nil)
+		if _, err := rl.RoundTrip(req); err != nil {
+			t.Errorf("RoundTrip() error = %v", err)
+		}
+		if req.Header.Get(TraceParentHeader) != "" {
+			t.Error("RoundTrip modified the request")
+		}
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/lots", nil)
+	r.Header.Set(TraceParentHeader, incoming)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if handlerSpan.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || handlerSpan.SpanID == "00f067aa0ba902b7" {
+		t.Errorf("handler span %+v isn't a child of %s", handlerSpan, incoming)
+	}
+	outbound, ok := ParseTraceParent(upstream)
+	if !ok || outbound.TraceID != handlerSpan.TraceID || outbound.SpanID == handlerSpan.SpanID {
+		t.Errorf("outbound traceparent %q isn't a child of %+v", upstream, handlerSpan)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected handler, outbound and request logs, got %q", lines)
+	}
+	wantSpans := []string{handlerSpan.SpanID, outbound.SpanID, handlerSpan.SpanID}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		if entry[TraceIDKey] != handlerSpan.TraceID || entry[SpanIDKey] != wantSpans[i] {
+			t.Errorf("line %d has trace %v and span %v, want %s and %s",
+				i, entry[TraceIDKey], entry[SpanIDKey], handlerSpan.TraceID, wantSpans[i])
+		}
+	}
+
+	t.Run("New Trace", func(t *testing.T) {
+		var buf syncBuffer
+		rl := NewRequestLogger(RequestLoggerConfig{
+			Logger: NewLogger(LoggerConfig{Level: LogLevelDebug, Format: LogFormatJSON, Output: &buf}),
+		})
+		ctx := context.Background()
+		h := rl.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/lots", nil))
+
+		tp, ok := TraceParentFromContext(ctx)
+		if !ok || !strings.Contains(buf.String(), `"traceId":"`+tp.TraceID+`"`) {
+			t.Errorf("expected a new trace %+v in %q", tp, buf.String())
+		}
+	})
+}